@@ -0,0 +1,256 @@
+package log
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	stdLog "log"
+)
+
+const defaultAsyncBuffer = 1024
+
+// Policy determines how an AsyncWriter handles a Write call made while its buffer is full. See DropOldest,
+// DropNewest, Block, and BlockWithTimeout.
+type Policy struct {
+	name    string
+	timeout time.Duration
+}
+
+var (
+	// DropOldest evicts the oldest buffered entry to make room for the new one, so Write never blocks the
+	// caller at the cost of losing the oldest unflushed entries first.
+	DropOldest = Policy{name: "drop_oldest"}
+
+	// DropNewest discards the entry being written when the buffer is full, so Write never blocks the caller
+	// at the cost of losing the newest entries instead of the oldest.
+	DropNewest = Policy{name: "drop_newest"}
+
+	// Block makes Write wait until buffer space is available, applying backpressure to the caller rather
+	// than losing any entry.
+	Block = Policy{name: "block"}
+)
+
+// BlockWithTimeout returns a Policy that behaves like Block, but gives up and drops the entry if buffer
+// space doesn't free up within d.
+func BlockWithTimeout(d time.Duration) Policy {
+	return Policy{name: "block_timeout", timeout: d}
+}
+
+// Stats reports an AsyncWriter's cumulative counters and current queue depth, so operators can alert on
+// Dropped growing, or on Depth staying near its configured buffer size.
+type Stats struct {
+	Enqueued uint64
+	Dropped  uint64
+	Flushed  uint64
+	Depth    int
+}
+
+// AsyncWriter wraps an io.Writer, moving the actual write off the caller's goroutine onto a bounded ring
+// buffer drained by a single background flusher goroutine. Construct one with NewAsyncWriter, and call
+// Close to flush pending entries and stop the flusher.
+//
+// AsyncWriter also implements the zerolog.LevelWriter WriteLevel method: a LevelFatal write bypasses the
+// buffer, synchronously draining whatever is already queued before writing itself, so the entry reaches the
+// underlying io.Writer before zerolog's Fatal path calls os.Exit.
+type AsyncWriter struct {
+	w      io.Writer
+	policy Policy
+	queue  chan []byte
+	done   chan struct{}
+
+	// writeMu serializes access to w across the background flusher (run) and the synchronous Fatal bypass
+	// (drain, in WriteLevel), since both can otherwise call w.Write concurrently.
+	writeMu sync.Mutex
+
+	// closeMu guards closed and the closing of queue, so enqueue can never race Close: it holds a read lock
+	// while checking closed and sending, and Close takes the write lock to flip closed and close(queue),
+	// ruling out a send on an already-closed channel.
+	closeMu sync.RWMutex
+	closed  bool
+
+	enqueued atomic.Uint64
+	dropped  atomic.Uint64
+	flushed  atomic.Uint64
+}
+
+// NewAsyncWriter returns an AsyncWriter wrapping w with a ring buffer sized to hold buffer entries (falling
+// back to a reasonable default if buffer <= 0), applying policy whenever the buffer is full.
+func NewAsyncWriter(w io.Writer, buffer int, policy Policy) *AsyncWriter {
+	if buffer <= 0 {
+		buffer = defaultAsyncBuffer
+	}
+
+	aw := &AsyncWriter{
+		w:      w,
+		policy: policy,
+		queue:  make(chan []byte, buffer),
+		done:   make(chan struct{}),
+	}
+	go aw.run()
+	return aw
+}
+
+// Write implements io.Writer, enqueuing a copy of p per the writer's Policy.
+func (w *AsyncWriter) Write(p []byte) (int, error) {
+	return w.enqueue(p)
+}
+
+// WriteLevel implements zerolog.LevelWriter. A LevelFatal write is synchronous: it drains any entries
+// already queued, then writes p directly, so it has reached the underlying io.Writer by the time this
+// returns and zerolog's Fatal path calls os.Exit.
+func (w *AsyncWriter) WriteLevel(level Level, p []byte) (int, error) {
+	if level == LevelFatal {
+		w.drain()
+
+		w.writeMu.Lock()
+		n, err := w.w.Write(p)
+		w.writeMu.Unlock()
+
+		if err == nil {
+			w.flushed.Add(1)
+		}
+		return n, err
+	}
+	return w.enqueue(p)
+}
+
+// Stats returns the writer's current counters and queue depth.
+func (w *AsyncWriter) Stats() Stats {
+	return Stats{
+		Enqueued: w.enqueued.Load(),
+		Dropped:  w.dropped.Load(),
+		Flushed:  w.flushed.Load(),
+		Depth:    len(w.queue),
+	}
+}
+
+// Close stops accepting new writes and waits for the flusher to drain the buffer to the underlying
+// io.Writer, or for ctx to be done, whichever comes first. Acquiring closeMu runs in its own goroutine so a
+// Write blocked inside enqueue (e.g. under the Block policy, holding closeMu for a read) can't make Close
+// miss ctx's deadline; that goroutine still completes the close once enqueue unblocks, even after Close
+// itself has returned ctx.Err().
+func (w *AsyncWriter) Close(ctx context.Context) error {
+	closed := make(chan struct{})
+	go func() {
+		w.closeMu.Lock()
+		if !w.closed {
+			w.closed = true
+			close(w.queue)
+		}
+		w.closeMu.Unlock()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *AsyncWriter) enqueue(p []byte) (int, error) {
+	n := len(p)
+
+	w.closeMu.RLock()
+	defer w.closeMu.RUnlock()
+	if w.closed {
+		w.dropped.Add(1)
+		return n, nil
+	}
+
+	b := append([]byte(nil), p...)
+	switch w.policy.name {
+	case "block":
+		w.queue <- b
+		w.enqueued.Add(1)
+	case "block_timeout":
+		timer := time.NewTimer(w.policy.timeout)
+		select {
+		case w.queue <- b:
+			w.enqueued.Add(1)
+			if !timer.Stop() {
+				<-timer.C
+			}
+		case <-timer.C:
+			w.dropped.Add(1)
+		}
+	case "drop_newest":
+		select {
+		case w.queue <- b:
+			w.enqueued.Add(1)
+		default:
+			w.dropped.Add(1)
+		}
+	default: // drop_oldest
+		// Bounded by the buffer's own capacity: that's the most evictions a single Write could ever need,
+		// even racing the background flusher for the same slots, so this can't spin indefinitely under
+		// sustained contention.
+		for i := 0; i <= cap(w.queue); i++ {
+			select {
+			case w.queue <- b:
+				w.enqueued.Add(1)
+				return n, nil
+			default:
+			}
+
+			select {
+			case <-w.queue:
+				w.dropped.Add(1)
+			default:
+			}
+		}
+		w.dropped.Add(1)
+	}
+	return n, nil
+}
+
+// drain writes every entry currently queued, without waiting for more to arrive; it's used by WriteLevel's
+// Fatal bypass to flush ahead of an imminent os.Exit. writeMu keeps it from racing run over w.
+func (w *AsyncWriter) drain() {
+	for {
+		select {
+		case b, ok := <-w.queue:
+			if !ok {
+				return
+			}
+
+			w.writeMu.Lock()
+			_, err := w.w.Write(b)
+			w.writeMu.Unlock()
+
+			if err != nil {
+				stdLog.Printf("log: async writer: %v", err)
+				continue
+			}
+			w.flushed.Add(1)
+		default:
+			return
+		}
+	}
+}
+
+func (w *AsyncWriter) run() {
+	defer close(w.done)
+	for b := range w.queue {
+		w.writeMu.Lock()
+		_, err := w.w.Write(b)
+		w.writeMu.Unlock()
+
+		if err != nil {
+			stdLog.Printf("log: async writer: %v", err)
+			continue
+		}
+		w.flushed.Add(1)
+	}
+}
+
@@ -0,0 +1,174 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingWriter blocks every Write until release is closed, signaling entered as each Write call starts
+// blocking. Tests use entered to know a buffered entry has actually been picked up by AsyncWriter.run,
+// rather than racing against it.
+type blockingWriter struct {
+	entered chan struct{}
+	release chan struct{}
+	once    sync.Once
+
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func newBlockingWriter() *blockingWriter {
+	return &blockingWriter{entered: make(chan struct{}, 8), release: make(chan struct{})}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	w.entered <- struct{}{}
+	<-w.release
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *blockingWriter) unblock() {
+	w.once.Do(func() { close(w.release) })
+}
+
+func mustWrite(t *testing.T, w *AsyncWriter, s string) {
+	t.Helper()
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatalf("Write(%q): %v", s, err)
+	}
+}
+
+func TestAsyncWriterDropOldestEvictsUnderFullBuffer(t *testing.T) {
+	w := newBlockingWriter()
+	aw := NewAsyncWriter(w, 2, DropOldest)
+	t.Cleanup(func() { _ = aw.Close(context.Background()) })
+	t.Cleanup(w.unblock)
+
+	mustWrite(t, aw, "a")
+	<-w.entered // run() has taken "a" off the queue and is now blocked writing it
+
+	mustWrite(t, aw, "b")
+	mustWrite(t, aw, "c")
+	mustWrite(t, aw, "d") // queue (cap 2) is full with b,c: this evicts b to make room
+
+	if stats := aw.Stats(); stats.Dropped != 1 {
+		t.Errorf("Stats().Dropped = %d, want 1", stats.Dropped)
+	}
+}
+
+func TestAsyncWriterDropNewestRejectsIncomingUnderFullBuffer(t *testing.T) {
+	w := newBlockingWriter()
+	aw := NewAsyncWriter(w, 1, DropNewest)
+	t.Cleanup(func() { _ = aw.Close(context.Background()) })
+	t.Cleanup(w.unblock)
+
+	mustWrite(t, aw, "a")
+	<-w.entered // run() has taken "a" off the queue and is now blocked writing it
+
+	mustWrite(t, aw, "b") // fills the 1-slot queue
+	mustWrite(t, aw, "c") // queue full: dropped rather than evicting "b"
+
+	if stats := aw.Stats(); stats.Dropped != 1 {
+		t.Errorf("Stats().Dropped = %d, want 1", stats.Dropped)
+	}
+}
+
+func TestAsyncWriterBlockWaitsForSpace(t *testing.T) {
+	w := newBlockingWriter()
+	aw := NewAsyncWriter(w, 1, Block)
+	t.Cleanup(func() { _ = aw.Close(context.Background()) })
+	t.Cleanup(w.unblock)
+
+	mustWrite(t, aw, "a")
+	<-w.entered // run() has taken "a" off the queue and is now blocked writing it
+
+	mustWrite(t, aw, "b") // fills the 1-slot queue
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := aw.Write([]byte("c")) // must block until "a" drains and frees a slot
+		done <- err
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Write under the Block policy returned before the queue had space")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	w.unblock()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf(`Write("c"): %v`, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Write under the Block policy never unblocked after space freed")
+	}
+}
+
+func TestAsyncWriterBlockWithTimeoutDropsAfterDeadline(t *testing.T) {
+	w := newBlockingWriter()
+	aw := NewAsyncWriter(w, 1, BlockWithTimeout(20*time.Millisecond))
+	t.Cleanup(func() { _ = aw.Close(context.Background()) })
+	t.Cleanup(w.unblock)
+
+	mustWrite(t, aw, "a")
+	<-w.entered // run() has taken "a" off the queue and is now blocked writing it
+
+	mustWrite(t, aw, "b")  // fills the 1-slot queue
+	mustWrite(t, aw, "c")  // should time out waiting for space and drop, not block forever
+
+	if stats := aw.Stats(); stats.Dropped != 1 {
+		t.Errorf("Stats().Dropped = %d, want 1", stats.Dropped)
+	}
+}
+
+// syncWriter is a plain non-blocking io.Writer guarded by a mutex, for tests that don't need to force the
+// queue full.
+type syncWriter struct {
+	mu  *sync.Mutex
+	buf *bytes.Buffer
+}
+
+func (w syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+// TestAsyncWriterWriteLevelFatalDrainsAfterClose guards against AsyncWriter.drain spinning forever: once
+// Close has closed the queue, a receive on it is always immediately ready with ok == false, so drain must
+// check ok rather than loop on it, or a LevelFatal write arriving after Close would hang instead of
+// reaching the underlying writer ahead of zerolog's os.Exit.
+func TestAsyncWriterWriteLevelFatalDrainsAfterClose(t *testing.T) {
+	var mu sync.Mutex
+	var buf bytes.Buffer
+	aw := NewAsyncWriter(syncWriter{mu: &mu, buf: &buf}, 4, DropOldest)
+
+	if err := aw.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := aw.WriteLevel(LevelFatal, []byte("fatal-after-close"))
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("WriteLevel(LevelFatal) after Close: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WriteLevel(LevelFatal) hung after Close; drain is busy-looping on the closed, empty queue")
+	}
+}
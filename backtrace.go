@@ -0,0 +1,91 @@
+package log
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// backtraceSpec maps a file path to the set of line numbers within it that trigger a stack dump.
+type backtraceSpec map[string]map[int]struct{}
+
+var backtraceAt atomic.Pointer[backtraceSpec]
+
+// SetBacktraceAt configures file:line locations that trigger a stack dump on every log event emitted from
+// that location, from a comma-separated list, e.g.:
+//
+//	internal/auth/token.go:142,payment/charge.go:58
+//
+// When the emitting call site matches an entry, the Record is enriched with a stack attribute holding a
+// dump of the calling goroutine's stack, regardless of level; if the event's level is LevelError or above,
+// the dump covers all goroutines instead. Passing an empty spec clears all triggers. SetBacktraceAt is safe
+// for concurrent use and takes effect for subsequent log calls; this mirrors glog's operator-friendly
+// debugging hook, letting a stuck production process be diagnosed without a redeploy.
+func SetBacktraceAt(spec string) error {
+	locations := backtraceSpec{}
+	spec = strings.TrimSpace(spec)
+	if spec != "" {
+		for _, entry := range strings.Split(spec, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+
+			i := strings.LastIndex(entry, ":")
+			if i < 0 {
+				return fmt.Errorf("log: invalid backtrace_at entry: %s", entry)
+			}
+
+			file := strings.TrimPrefix(strings.TrimSpace(entry[:i]), "/")
+			line, err := strconv.Atoi(strings.TrimSpace(entry[i+1:]))
+			if file == "" || err != nil {
+				return fmt.Errorf("log: invalid backtrace_at entry: %s", entry)
+			}
+
+			lines := locations[file]
+			if lines == nil {
+				lines = make(map[int]struct{})
+				locations[file] = lines
+			}
+			lines[line] = struct{}{}
+		}
+	}
+
+	backtraceAt.Store(&locations)
+	return nil
+}
+
+// backtraceMatch reports whether file:line is configured, via SetBacktraceAt, to trigger a stack dump.
+// Matching is by exact or suffix match against file, the same convention resolveVModule uses, so a spec
+// entry need not repeat a package's full import path.
+func backtraceMatch(file string, line int) bool {
+	spec := backtraceAt.Load()
+	if spec == nil || len(*spec) == 0 {
+		return false
+	}
+
+	for f, lines := range *spec {
+		if _, ok := lines[line]; !ok {
+			continue
+		}
+		if f == file || strings.HasSuffix(file, "/"+f) {
+			return true
+		}
+	}
+	return false
+}
+
+// dumpStack returns a formatted dump of the calling goroutine's stack, or of all goroutines when
+// allGoroutines is true, growing the buffer until runtime.Stack reports the dump fit.
+func dumpStack(allGoroutines bool) string {
+	buf := make([]byte, 8*1024)
+	for {
+		n := runtime.Stack(buf, allGoroutines)
+		if n < len(buf) {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
@@ -0,0 +1,65 @@
+package log
+
+import "testing"
+
+func TestSetBacktraceAt(t *testing.T) {
+	t.Cleanup(func() { _ = SetBacktraceAt("") })
+
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr bool
+	}{
+		{name: "empty spec clears triggers", spec: ""},
+		{name: "single entry", spec: "internal/auth/token.go:142"},
+		{name: "multiple entries", spec: "internal/auth/token.go:142,payment/charge.go:58"},
+		{name: "entries with surrounding whitespace", spec: " internal/auth/token.go:142 , payment/charge.go:58 "},
+		{name: "missing colon", spec: "internal/auth/token.go", wantErr: true},
+		{name: "non-numeric line", spec: "internal/auth/token.go:abc", wantErr: true},
+		{name: "empty file", spec: ":142", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetBacktraceAt(tt.spec); (err != nil) != tt.wantErr {
+				t.Errorf("SetBacktraceAt(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBacktraceMatch(t *testing.T) {
+	t.Cleanup(func() { _ = SetBacktraceAt("") })
+
+	if err := SetBacktraceAt("internal/auth/token.go:142"); err != nil {
+		t.Fatalf("SetBacktraceAt: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		file string
+		line int
+		want bool
+	}{
+		{name: "exact match", file: "internal/auth/token.go", line: 142, want: true},
+		{name: "suffix match against an absolute path", file: "/src/repo/internal/auth/token.go", line: 142, want: true},
+		{name: "line mismatch", file: "internal/auth/token.go", line: 143, want: false},
+		{name: "file mismatch", file: "other/file.go", line: 142, want: false},
+		{name: "partial path segment is not a suffix match", file: "other/internal/auth/nottoken.go", line: 142, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := backtraceMatch(tt.file, tt.line); got != tt.want {
+				t.Errorf("backtraceMatch(%q, %d) = %v, want %v", tt.file, tt.line, got, tt.want)
+			}
+		})
+	}
+
+	if err := SetBacktraceAt(""); err != nil {
+		t.Fatalf("SetBacktraceAt(\"\"): %v", err)
+	}
+	if backtraceMatch("internal/auth/token.go", 142) {
+		t.Error("backtraceMatch still matches after clearing the spec")
+	}
+}
@@ -40,4 +40,53 @@ const (
 	//
 	// value: <root>.log.file.retention.backups
 	FileRetentionBackups = File + ".retention.backups"
+
+	// FileAsync configuration path.
+	//
+	// value: <root>.log.file.async
+	FileAsync = File + ".async"
+
+	// FileBuffer configuration path.
+	//
+	// value: <root>.log.file.buffer
+	FileBuffer = File + ".buffer"
+
+	// FilePolicy configuration path.
+	//
+	// value: <root>.log.file.policy
+	FilePolicy = File + ".policy"
+
+	// VModule configuration path.
+	//
+	// value: <root>.log.vmodule
+	VModule = "log.vmodule"
+
+	// BacktraceAt configuration path.
+	//
+	// value: <root>.log.backtrace_at
+	BacktraceAt = "log.backtrace_at"
+
+	// OTel configuration path.
+	//
+	// value: <root>.log.otel
+	OTel = "log.otel"
+
+	// OTelEnabled configuration path.
+	//
+	// value: <root>.log.otel.enabled
+	OTelEnabled = OTel + ".enabled"
+
+	// OTelEndpoint configuration path. This package does not read it directly: NewOTelHandler takes an
+	// already-constructed sdklog.Exporter, so the caller is responsible for reading this key when building
+	// that exporter (e.g. otlploggrpc.New, otlploghttp.New). The constant exists so callers agree on where
+	// that endpoint is configured.
+	//
+	// value: <root>.log.otel.endpoint
+	OTelEndpoint = OTel + ".endpoint"
+
+	// OTelProtocol configuration path. Like OTelEndpoint, this package does not read it directly; it exists
+	// for the caller constructing NewOTelHandler's exporter to read.
+	//
+	// value: <root>.log.otel.protocol
+	OTelProtocol = OTel + ".protocol"
 )
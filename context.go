@@ -0,0 +1,23 @@
+package log
+
+import "context"
+
+// ctxKey is the unexported type used to key the Handler NewContext stores in a context.Context, so it can't
+// collide with keys set by other packages.
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx carrying handler, retrievable via FromContext. Middleware (see the
+// middleware/http and middleware/grpc subpackages) uses this to bind a request-scoped Handler — typically
+// one derived via Handler.WithAttrs to carry a request/correlation ID — so that every log call made with
+// Context(ctx) while handling that request is routed to it automatically, picking up its bound attributes.
+func NewContext(ctx context.Context, handler Handler) context.Context {
+	return context.WithValue(ctx, ctxKey{}, handler)
+}
+
+// FromContext returns the Handler bound to ctx via NewContext, or Default() if ctx carries none.
+func FromContext(ctx context.Context) Handler {
+	if handler, ok := ctx.Value(ctxKey{}).(Handler); ok {
+		return handler
+	}
+	return Default()
+}
@@ -0,0 +1,79 @@
+package log
+
+import (
+	"context"
+)
+
+// Handler is the pluggable backend that receives populated Records and turns them into emitted log
+// events. The package ships a Handler backed by zerolog (the historical behavior of this package), one
+// backed by log/slog, and one (OTelHandler) that ships records via OTLP; other backends (zap, logrus, ...)
+// can be plugged in via SetDefault or WithHandler without forking this package.
+type Handler interface {
+	// Enabled reports whether the Handler will emit an event at the given Level.
+	Enabled(level Level) bool
+
+	// Handle emits a single log event derived from r. Handle is only called once the caller (handleEvent, via
+	// Enabled or a SetVModule override) has already decided the event should be emitted, so implementations
+	// must emit unconditionally rather than re-deriving their own enabled/disabled decision from level; doing
+	// so would silently drop events a SetVModule override explicitly permitted past a less verbose Handler.
+	Handle(ctx context.Context, r *Record, level Level, msg string) error
+
+	// WithAttrs returns a copy of the Handler that applies the given Record options to every subsequent
+	// event it handles.
+	WithAttrs(args ...func(*Record)) Handler
+
+	// WithGroup returns a copy of the Handler that nests the attributes of subsequent events under name.
+	WithGroup(name string) Handler
+}
+
+// Tee returns a Handler that fans out every event to each of handlers, in order, so a program can, for
+// example, send structured JSON to a file while also emitting a colored console stream. Tee is Enabled for
+// a Level if any of handlers is. Handle delivers the event only to the handlers Enabled for its level, so
+// unlike the other Handlers in this package it does re-check Enabled: an event a SetVModule override let
+// through below every handler's level is not emitted by any of them. Handle returns the first error
+// encountered, after attempting delivery to every handler Enabled for the level.
+func Tee(handlers ...Handler) Handler {
+	return teeHandler{handlers: handlers}
+}
+
+type teeHandler struct {
+	handlers []Handler
+}
+
+func (t teeHandler) Enabled(level Level) bool {
+	for _, h := range t.handlers {
+		if h.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t teeHandler) Handle(ctx context.Context, r *Record, level Level, msg string) error {
+	var first error
+	for _, h := range t.handlers {
+		if !h.Enabled(level) {
+			continue
+		}
+		if err := h.Handle(ctx, r, level, msg); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+func (t teeHandler) WithAttrs(args ...func(*Record)) Handler {
+	handlers := make([]Handler, len(t.handlers))
+	for i, h := range t.handlers {
+		handlers[i] = h.WithAttrs(args...)
+	}
+	return teeHandler{handlers: handlers}
+}
+
+func (t teeHandler) WithGroup(name string) Handler {
+	handlers := make([]Handler, len(t.handlers))
+	for i, h := range t.handlers {
+		handlers[i] = h.WithGroup(name)
+	}
+	return teeHandler{handlers: handlers}
+}
@@ -0,0 +1,177 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// OTelHandler is a Handler that converts Records into OTel log records and ships them via OTLP through an
+// sdklog.Exporter, batched by a sdklog.BatchProcessor. Construct one with NewOTelHandler, and call Shutdown
+// when done with it to flush any buffered records.
+type OTelHandler struct {
+	provider *sdklog.LoggerProvider
+	logger   otellog.Logger
+	level    Level
+	group    string
+	attrs    []otellog.KeyValue
+}
+
+// OTelHandlerOption configures NewOTelHandler.
+type OTelHandlerOption func(*otelHandlerOptions)
+
+type otelHandlerOptions struct {
+	loggerName string
+	level      Level
+	resource   []attribute.KeyValue
+}
+
+// WithOTelLoggerName sets the name NewOTelHandler registers its otellog.Logger under (the "instrumentation
+// scope" in OTel terms). The default is this module's import path.
+func WithOTelLoggerName(name string) OTelHandlerOption {
+	return func(o *otelHandlerOptions) {
+		o.loggerName = strings.TrimSpace(name)
+	}
+}
+
+// WithOTelLevel sets the minimum Level the returned Handler reports as Enabled. The default is LevelInfo.
+func WithOTelLevel(level Level) OTelHandlerOption {
+	return func(o *otelHandlerOptions) {
+		o.level = level
+	}
+}
+
+// WithOTelResource sets resource attributes (e.g. service.name, service.version) attached to every log
+// record the returned Handler emits.
+func WithOTelResource(attrs ...attribute.KeyValue) OTelHandlerOption {
+	return func(o *otelHandlerOptions) {
+		o.resource = attrs
+	}
+}
+
+// NewOTelHandler returns a Handler that batches Records through exporter via OTLP. The caller owns exporter
+// and is still responsible for constructing it (e.g. otlploggrpc.New or otlploghttp.New) from
+// log.otel.endpoint/log.otel.protocol; NewOTelHandler only wires it into an sdklog.LoggerProvider.
+func NewOTelHandler(exporter sdklog.Exporter, opts ...OTelHandlerOption) *OTelHandler {
+	o := &otelHandlerOptions{
+		loggerName: "github.com/transientvariable/log-go",
+		level:      LevelInfo,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	providerOpts := []sdklog.LoggerProviderOption{sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter))}
+	if len(o.resource) > 0 {
+		providerOpts = append(providerOpts, sdklog.WithResource(resource.NewSchemaless(o.resource...)))
+	}
+
+	provider := sdklog.NewLoggerProvider(providerOpts...)
+	return &OTelHandler{
+		provider: provider,
+		logger:   provider.Logger(o.loggerName),
+		level:    o.level,
+	}
+}
+
+// Shutdown flushes any records buffered by the Handler's batch processor and releases its resources.
+// Shutdown should be called once, typically deferred at program exit.
+func (h *OTelHandler) Shutdown(ctx context.Context) error {
+	return h.provider.Shutdown(ctx)
+}
+
+// Enabled implements Handler.
+func (h *OTelHandler) Enabled(level Level) bool {
+	return level >= h.level
+}
+
+// Handle implements Handler.
+func (h *OTelHandler) Handle(ctx context.Context, r *Record, level Level, msg string) error {
+	now := time.Now()
+
+	var rec otellog.Record
+	rec.SetTimestamp(now)
+	rec.SetObservedTimestamp(now)
+	rec.SetSeverity(toOTelSeverity(level))
+	rec.SetSeverityText(level.String())
+	rec.SetBody(otellog.StringValue(msg))
+
+	if err := r.Err(); err != nil {
+		rec.AddAttributes(otellog.String("error", err.Error()))
+	}
+
+	rec.AddAttributes(h.attrs...)
+	r.Attrs(func(key string, kind Kind, value any) {
+		rec.AddAttributes(toOTelLogAttr(h.group+key, kind, value))
+	})
+
+	h.logger.Emit(ctx, rec)
+	return nil
+}
+
+// WithAttrs implements Handler.
+func (h *OTelHandler) WithAttrs(args ...func(*Record)) Handler {
+	r := acquireRecord()
+	defer releaseRecord(r)
+
+	for _, arg := range args {
+		arg(r)
+	}
+
+	attrs := make([]otellog.KeyValue, len(h.attrs), len(h.attrs)+len(r.attrs))
+	copy(attrs, h.attrs)
+	r.Attrs(func(key string, kind Kind, value any) {
+		attrs = append(attrs, toOTelLogAttr(h.group+key, kind, value))
+	})
+
+	return &OTelHandler{provider: h.provider, logger: h.logger, level: h.level, group: h.group, attrs: attrs}
+}
+
+// WithGroup implements Handler. OTel log records have no native nested-attribute concept here, so
+// subsequent attribute keys are namespaced with "name." instead.
+func (h *OTelHandler) WithGroup(name string) Handler {
+	if name = strings.TrimSpace(name); name == "" {
+		return h
+	}
+	return &OTelHandler{provider: h.provider, logger: h.logger, level: h.level, group: h.group + name + ".", attrs: h.attrs}
+}
+
+func toOTelSeverity(level Level) otellog.Severity {
+	switch level {
+	case LevelTrace:
+		return otellog.SeverityTrace
+	case LevelDebug:
+		return otellog.SeverityDebug
+	case LevelInfo:
+		return otellog.SeverityInfo
+	case LevelWarn:
+		return otellog.SeverityWarn
+	case LevelError:
+		return otellog.SeverityError
+	case LevelFatal:
+		return otellog.SeverityFatal
+	case LevelPanic:
+		return otellog.SeverityFatal4
+	default:
+		return otellog.SeverityUndefined
+	}
+}
+
+func toOTelLogAttr(key string, kind Kind, value any) otellog.KeyValue {
+	switch v := decodeAttr(kind, value).(type) {
+	case bool:
+		return otellog.Bool(key, v)
+	case int64:
+		return otellog.Int64(key, v)
+	case float64:
+		return otellog.Float64(key, v)
+	default:
+		return otellog.String(key, fmt.Sprintf("%v", v))
+	}
+}
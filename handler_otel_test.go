@@ -0,0 +1,153 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// memoryExporter is a minimal sdklog.Exporter that stores every exported Record in memory, so tests can
+// inspect what NewOTelHandler actually shipped without standing up a real OTLP endpoint.
+type memoryExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *memoryExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.records = append(e.records, records...)
+	return nil
+}
+
+func (e *memoryExporter) Shutdown(context.Context) error   { return nil }
+func (e *memoryExporter) ForceFlush(context.Context) error { return nil }
+
+func (e *memoryExporter) exported() []sdklog.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out := make([]sdklog.Record, len(e.records))
+	copy(out, e.records)
+	return out
+}
+
+func TestOTelHandlerEnabledUsesConfiguredLevel(t *testing.T) {
+	h := NewOTelHandler(&memoryExporter{}, WithOTelLevel(LevelWarn))
+	t.Cleanup(func() { _ = h.Shutdown(context.Background()) })
+
+	if h.Enabled(LevelInfo) {
+		t.Error("Enabled(LevelInfo) = true, want false: handler configured at LevelWarn")
+	}
+	if !h.Enabled(LevelError) {
+		t.Error("Enabled(LevelError) = false, want true: handler configured at LevelWarn")
+	}
+}
+
+// TestOTelHandlerHandleShipsAttributesAndSeverity covers the Record -> otellog.Record conversion: attribute
+// kind decoding (via toOTelLogAttr/decodeAttr), the error attribute, and severity/body mapping.
+func TestOTelHandlerHandleShipsAttributesAndSeverity(t *testing.T) {
+	exporter := &memoryExporter{}
+	h := NewOTelHandler(exporter)
+
+	wantErr := errors.New("request failed: upstream unavailable")
+
+	r := acquireRecord()
+	r.addAttr("count", int64(3), kindInt64)
+	r.err = wantErr
+
+	if err := h.Handle(context.Background(), r, LevelError, "request failed"); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	releaseRecord(r)
+
+	if err := h.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	records := exporter.exported()
+	if len(records) != 1 {
+		t.Fatalf("got %d exported records, want 1", len(records))
+	}
+
+	rec := records[0]
+	if rec.Severity() != otellog.SeverityError {
+		t.Errorf("Severity() = %v, want %v", rec.Severity(), otellog.SeverityError)
+	}
+	if rec.Body().AsString() != "request failed" {
+		t.Errorf("Body() = %q, want %q", rec.Body().AsString(), "request failed")
+	}
+
+	attrs := map[string]otellog.Value{}
+	rec.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+
+	if got, ok := attrs["count"]; !ok || got.AsInt64() != 3 {
+		t.Errorf("count attribute = %v, want 3", got)
+	}
+	if got, ok := attrs["error"]; !ok || got.AsString() != wantErr.Error() {
+		t.Errorf("error attribute = %v, want %q", got, wantErr.Error())
+	}
+}
+
+// TestOTelHandlerWithGroupNamespacesAttributes covers WithGroup's "name." prefixing convention, shared with
+// zerologHandler since OTel log records have no native nested-group concept either.
+func TestOTelHandlerWithGroupNamespacesAttributes(t *testing.T) {
+	exporter := &memoryExporter{}
+	h := NewOTelHandler(exporter)
+
+	grouped := h.WithGroup("http").WithAttrs(String("status", "200"))
+
+	r := acquireRecord()
+	defer releaseRecord(r)
+
+	if err := grouped.Handle(context.Background(), r, LevelInfo, "msg"); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if err := h.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	records := exporter.exported()
+	if len(records) != 1 {
+		t.Fatalf("got %d exported records, want 1", len(records))
+	}
+
+	var found bool
+	records[0].WalkAttributes(func(kv otellog.KeyValue) bool {
+		if kv.Key == "http.status" {
+			found = true
+		}
+		return true
+	})
+	if !found {
+		t.Error(`expected an "http.status" attribute, WithGroup did not namespace it`)
+	}
+}
+
+func TestToOTelSeverityMapsEveryLevel(t *testing.T) {
+	tests := []struct {
+		level Level
+		want  otellog.Severity
+	}{
+		{LevelTrace, otellog.SeverityTrace},
+		{LevelDebug, otellog.SeverityDebug},
+		{LevelInfo, otellog.SeverityInfo},
+		{LevelWarn, otellog.SeverityWarn},
+		{LevelError, otellog.SeverityError},
+		{LevelFatal, otellog.SeverityFatal},
+		{LevelPanic, otellog.SeverityFatal4},
+		{LevelNone, otellog.SeverityUndefined},
+	}
+
+	for _, tt := range tests {
+		if got := toOTelSeverity(tt.level); got != tt.want {
+			t.Errorf("toOTelSeverity(%v) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
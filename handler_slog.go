@@ -0,0 +1,104 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+)
+
+// slogHandler adapts a *slog.Logger to Handler, so a program already standardized on log/slog can still be
+// driven through this package's call-site API (Debug, Info, V, SetVModule, ...) without forking it.
+type slogHandler struct {
+	logger *slog.Logger
+}
+
+// NewSlogHandler returns a Handler that emits events through logger.
+//
+// Because Handler.Handle is not given the caller's program counter, events emitted through the returned
+// Handler carry no source location even if logger's Handler was built with AddSource; callers that need
+// source attribution should prefer NewZerologHandler.
+func NewSlogHandler(logger *slog.Logger) Handler {
+	return &slogHandler{logger: logger}
+}
+
+// Enabled implements Handler.
+func (h *slogHandler) Enabled(level Level) bool {
+	return h.logger.Enabled(context.Background(), toSlogLevel(level))
+}
+
+// Handle implements Handler. Per the Handler.Handle contract, it emits unconditionally once called rather
+// than re-checking h.logger.Enabled: that check re-derives h.logger's own configured level independent of
+// whatever decided Handle should be called (e.g. a SetVModule override), and would otherwise silently drop
+// an event handleEvent already decided to emit. This mirrors how slog.Handler.Handle implementations are
+// themselves documented to behave — Enabled is a separate, advisory check a caller may skip.
+func (h *slogHandler) Handle(ctx context.Context, r *Record, level Level, msg string) error {
+	slogLevel := toSlogLevel(level)
+	rec := slog.NewRecord(time.Now(), slogLevel, msg, 0)
+	if err := r.Err(); err != nil {
+		rec.AddAttrs(slog.Any("error", err))
+	}
+	r.Attrs(func(key string, kind Kind, value any) {
+		rec.AddAttrs(toSlogAttr(key, kind, value))
+	})
+
+	return h.logger.Handler().Handle(ctx, rec)
+}
+
+// WithAttrs implements Handler.
+func (h *slogHandler) WithAttrs(args ...func(*Record)) Handler {
+	r := acquireRecord()
+	defer releaseRecord(r)
+
+	for _, arg := range args {
+		arg(r)
+	}
+
+	var attrs []slog.Attr
+	r.Attrs(func(key string, kind Kind, value any) {
+		attrs = append(attrs, toSlogAttr(key, kind, value))
+	})
+
+	return &slogHandler{logger: slog.New(h.logger.Handler().WithAttrs(attrs))}
+}
+
+// WithGroup implements Handler.
+func (h *slogHandler) WithGroup(name string) Handler {
+	return &slogHandler{logger: slog.New(h.logger.Handler().WithGroup(name))}
+}
+
+func toSlogLevel(level Level) slog.Level {
+	switch level {
+	case LevelTrace, LevelDebug:
+		return slog.LevelDebug
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelError, LevelFatal, LevelPanic:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func toSlogAttr(key string, kind Kind, value any) slog.Attr {
+	switch kind {
+	case KindBool:
+		return slog.Bool(key, value.(bool))
+	case KindDuration:
+		return slog.Duration(key, time.Duration(value.(uint64)))
+	case KindFloat32:
+		return slog.Float64(key, float64(math.Float32frombits(value.(uint32))))
+	case KindFloat64:
+		return slog.Float64(key, math.Float64frombits(value.(uint64)))
+	case KindInt64:
+		return slog.Int64(key, value.(int64))
+	case KindString:
+		return slog.String(key, value.(string))
+	case KindTime:
+		return slog.Time(key, value.(time.Time))
+	case KindUint64:
+		return slog.Uint64(key, value.(uint64))
+	default:
+		return slog.Any(key, value)
+	}
+}
@@ -0,0 +1,119 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// recordingHandler is a minimal Handler, like fakeHandler in vmodule_test.go, but also tracks which
+// attribute keys WithAttrs applied and which group WithGroup nested into, and can be configured to fail
+// Handle, so Tee's fan-out, error-first, and composition semantics can be tested without a real backend.
+type recordingHandler struct {
+	enabledLevel Level
+	group        string
+	attrs        []string
+	handled      []Level
+	err          error
+}
+
+func (h *recordingHandler) Enabled(level Level) bool { return level >= h.enabledLevel }
+
+func (h *recordingHandler) Handle(_ context.Context, _ *Record, level Level, _ string) error {
+	h.handled = append(h.handled, level)
+	return h.err
+}
+
+func (h *recordingHandler) WithAttrs(args ...func(*Record)) Handler {
+	r := acquireRecord()
+	defer releaseRecord(r)
+
+	for _, arg := range args {
+		arg(r)
+	}
+
+	clone := *h
+	r.Attrs(func(key string, _ Kind, _ any) {
+		clone.attrs = append(clone.attrs, key)
+	})
+	return &clone
+}
+
+func (h *recordingHandler) WithGroup(name string) Handler {
+	clone := *h
+	clone.group += name + "."
+	return &clone
+}
+
+func TestTeeEnabledIfAnyHandlerEnabled(t *testing.T) {
+	tee := Tee(&recordingHandler{enabledLevel: LevelError}, &recordingHandler{enabledLevel: LevelDebug})
+
+	if !tee.Enabled(LevelDebug) {
+		t.Error("Enabled(LevelDebug) = false, want true: one handler accepts Debug")
+	}
+	if tee.Enabled(LevelTrace) {
+		t.Error("Enabled(LevelTrace) = true, want false: neither handler accepts Trace")
+	}
+}
+
+// TestTeeHandleFansOutAndReturnsFirstError covers Tee's documented semantics: every handler still Enabled
+// for level is attempted, even after an earlier one errors, and Handle returns the first error encountered.
+func TestTeeHandleFansOutAndReturnsFirstError(t *testing.T) {
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+	a := &recordingHandler{enabledLevel: LevelInfo, err: errA}
+	b := &recordingHandler{enabledLevel: LevelInfo, err: errB}
+	disabled := &recordingHandler{enabledLevel: LevelError}
+
+	tee := Tee(a, b, disabled)
+
+	r := acquireRecord()
+	defer releaseRecord(r)
+
+	if err := tee.Handle(context.Background(), r, LevelInfo, "msg"); !errors.Is(err, errA) {
+		t.Errorf("Handle error = %v, want %v (the first handler's error)", err, errA)
+	}
+
+	if len(a.handled) != 1 || len(b.handled) != 1 {
+		t.Errorf("a.handled = %v, b.handled = %v, want exactly one Handle call each", a.handled, b.handled)
+	}
+	if len(disabled.handled) != 0 {
+		t.Errorf("disabled.handled = %v, want none: it is not Enabled at LevelInfo", disabled.handled)
+	}
+}
+
+func TestTeeWithAttrsAppliesToEachHandler(t *testing.T) {
+	a := &recordingHandler{enabledLevel: LevelInfo}
+	b := &recordingHandler{enabledLevel: LevelInfo}
+
+	derived := Tee(a, b).WithAttrs(String("request_id", "abc"))
+	tee, ok := derived.(teeHandler)
+	if !ok {
+		t.Fatalf("WithAttrs returned %T, want teeHandler", derived)
+	}
+
+	for i, h := range tee.handlers {
+		rh := h.(*recordingHandler)
+		if len(rh.attrs) != 1 || rh.attrs[0] != "request_id" {
+			t.Errorf("handlers[%d].attrs = %v, want [request_id]", i, rh.attrs)
+		}
+	}
+}
+
+func TestTeeWithGroupAppliesToEachHandler(t *testing.T) {
+	a := &recordingHandler{enabledLevel: LevelInfo}
+	b := &recordingHandler{enabledLevel: LevelInfo}
+
+	derived := Tee(a, b).WithGroup("http")
+	tee, ok := derived.(teeHandler)
+	if !ok {
+		t.Fatalf("WithGroup returned %T, want teeHandler", derived)
+	}
+
+	for i, h := range tee.handlers {
+		rh := h.(*recordingHandler)
+		if rh.group != "http." {
+			t.Errorf("handlers[%d].group = %q, want %q", i, rh.group, "http.")
+		}
+	}
+}
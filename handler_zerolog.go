@@ -0,0 +1,151 @@
+package log
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// zerologHandler is the Handler New constructs by default, preserving the behavior this package had before
+// Handler was introduced.
+type zerologHandler struct {
+	logger *zerolog.Logger
+	group  string
+}
+
+// NewZerologHandler returns a Handler that emits events through logger. New uses NewZerologHandler to build
+// the default Handler unless overridden via WithHandler.
+func NewZerologHandler(logger *zerolog.Logger) Handler {
+	return &zerologHandler{logger: logger}
+}
+
+// Enabled implements Handler.
+func (h *zerologHandler) Enabled(level Level) bool {
+	return level >= h.logger.GetLevel()
+}
+
+// Handle implements Handler. Per the Handler.Handle contract, it emits unconditionally once called: the
+// level-specific zerolog methods (Debug, Trace, ...) re-check h.logger's own configured level internally and
+// hand back a nil *Event when level is below it, which would otherwise silently drop an event that
+// handleEvent already decided to emit (e.g. via a SetVModule override permitting a level more verbose than
+// h.logger is configured for). Handle works around this by dispatching through a copy of h.logger with its
+// level forced to level, so the internal check always passes.
+func (h *zerologHandler) Handle(ctx context.Context, r *Record, level Level, msg string) error {
+	logger := h.logger.Level(level)
+
+	var event *zerolog.Event
+	switch level {
+	case LevelDebug:
+		event = logger.Debug()
+	case LevelError:
+		event = logger.Error()
+	case LevelFatal:
+		event = logger.Fatal()
+	case LevelInfo:
+		event = logger.Info()
+	case LevelPanic:
+		event = logger.Panic()
+	case LevelTrace:
+		event = logger.Trace()
+	case LevelWarn:
+		event = logger.Warn()
+	default:
+		return nil
+	}
+
+	if event == nil {
+		return nil
+	}
+
+	event.CallerSkipFrame(r.Skip())
+	event.Ctx(ctx)
+	if err := r.Err(); err != nil {
+		event.Err(err)
+	}
+
+	r.Attrs(func(key string, kind Kind, value any) {
+		addZerologEventAttr(event, h.group+key, kind, value)
+	})
+	event.Msg(msg)
+	return nil
+}
+
+// WithAttrs implements Handler.
+func (h *zerologHandler) WithAttrs(args ...func(*Record)) Handler {
+	r := acquireRecord()
+	defer releaseRecord(r)
+
+	for _, arg := range args {
+		arg(r)
+	}
+
+	zctx := h.logger.With()
+	r.Attrs(func(key string, kind Kind, value any) {
+		zctx = addZerologContextAttr(zctx, h.group+key, kind, value)
+	})
+
+	logger := zctx.Logger()
+	return &zerologHandler{logger: &logger, group: h.group}
+}
+
+// WithGroup implements Handler. zerolog has no native concept of nested attribute groups, so subsequent
+// attribute keys are namespaced with "name." instead.
+func (h *zerologHandler) WithGroup(name string) Handler {
+	if name = strings.TrimSpace(name); name == "" {
+		return h
+	}
+
+	logger := *h.logger
+	return &zerologHandler{logger: &logger, group: h.group + name + "."}
+}
+
+func addZerologEventAttr(e *zerolog.Event, key string, kind Kind, value any) {
+	switch kind {
+	case KindAny:
+		e.Any(key, value)
+	case KindBool:
+		e.Bool(key, value.(bool))
+	case KindDuration:
+		e.Dur(key, time.Duration(value.(uint64)))
+	case KindFloat32:
+		e.Float32(key, math.Float32frombits(value.(uint32)))
+	case KindFloat64:
+		e.Float64(key, math.Float64frombits(value.(uint64)))
+	case KindInt64:
+		e.Int64(key, value.(int64))
+	case KindString:
+		e.Str(key, value.(string))
+	case KindTime:
+		e.Time(key, value.(time.Time))
+	case KindUint64:
+		e.Uint64(key, value.(uint64))
+	}
+}
+
+func addZerologContextAttr(c zerolog.Context, key string, kind Kind, value any) zerolog.Context {
+	switch kind {
+	case KindAny:
+		return c.Interface(key, value)
+	case KindBool:
+		return c.Bool(key, value.(bool))
+	case KindDuration:
+		return c.Dur(key, time.Duration(value.(uint64)))
+	case KindFloat32:
+		return c.Float32(key, math.Float32frombits(value.(uint32)))
+	case KindFloat64:
+		return c.Float64(key, math.Float64frombits(value.(uint64)))
+	case KindInt64:
+		return c.Int64(key, value.(int64))
+	case KindString:
+		return c.Str(key, value.(string))
+	case KindTime:
+		return c.Time(key, value.(time.Time))
+	case KindUint64:
+		return c.Uint64(key, value.(uint64))
+	default:
+		return c
+	}
+}
@@ -0,0 +1,73 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// TestZerologHandlerHandleEmitsRegardlessOfLoggerLevel guards against Handle silently dropping an event
+// handleEvent already decided to emit: zerolog's own Logger.Debug/Trace/... re-check the logger's configured
+// level internally and hand back a nil *Event when it's not met, which NewZerologHandler must not defer to
+// once Handle has been called, or a SetVModule override permitting a more verbose level than the Handler's
+// own would never actually reach the sink.
+func TestZerologHandlerHandleEmitsRegardlessOfLoggerLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf).Level(zerolog.WarnLevel)
+	h := NewZerologHandler(&logger)
+
+	r := acquireRecord()
+	defer releaseRecord(r)
+
+	if err := h.Handle(context.Background(), r, LevelDebug, "vmodule-permitted debug"); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+
+	if buf.Len() == 0 {
+		t.Error("Handle wrote nothing for a level below the logger's configured level; Handle must emit unconditionally once called")
+	}
+}
+
+// TestSlogHandlerHandleEmitsRegardlessOfLoggerLevel is the log/slog analogue of
+// TestZerologHandlerHandleEmitsRegardlessOfLoggerLevel: slog.Logger.Enabled gates on the underlying
+// slog.Handler's configured level independent of whatever decided Handle should be called.
+func TestSlogHandlerHandleEmitsRegardlessOfLoggerLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	h := NewSlogHandler(logger)
+
+	r := acquireRecord()
+	defer releaseRecord(r)
+
+	if err := h.Handle(context.Background(), r, LevelDebug, "vmodule-permitted debug"); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+
+	if buf.Len() == 0 {
+		t.Error("Handle wrote nothing for a level below the logger's configured level; Handle must emit unconditionally once called")
+	}
+}
+
+// TestLogDepthVModuleOverrideReachesZerologHandler exercises the full LogDepth -> handleEvent ->
+// zerologHandler.Handle path (not the fakeHandler in vmodule_test.go), confirming a SetVModule rule that
+// permits a more verbose level than the bound Handler's own actually produces output.
+func TestLogDepthVModuleOverrideReachesZerologHandler(t *testing.T) {
+	t.Cleanup(func() { _ = SetVModule("") })
+
+	if err := SetVModule("log-go=debug"); err != nil {
+		t.Fatalf("SetVModule: %v", err)
+	}
+
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf).Level(zerolog.WarnLevel)
+	ctx := NewContext(context.Background(), NewZerologHandler(&logger))
+
+	Debug("state", Context(ctx))
+
+	if buf.Len() == 0 {
+		t.Error("Debug produced no output despite a vmodule rule permitting it; the override is not reaching the real Handler")
+	}
+}
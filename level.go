@@ -1,7 +1,10 @@
 package log
 
 import (
+	"context"
 	"io"
+	"sync"
+	"sync/atomic"
 
 	"github.com/rs/zerolog"
 )
@@ -21,16 +24,68 @@ const (
 	LevelTrace    = zerolog.TraceLevel
 )
 
+// levelWriter is implemented by AsyncWriter; a LevelWriter whose Writer already satisfies it (e.g. one
+// shared across several LevelWriters by prepareFileWriters) delegates directly instead of wrapping again.
+type levelWriter interface {
+	WriteLevel(level Level, p []byte) (int, error)
+}
+
 // LevelWriter ...
+//
+// Setting Async wraps Writer in an AsyncWriter (see NewAsyncWriter) the first time WriteLevel is called,
+// sized per Buffer and governed by Policy, moving writes at or above Level off the caller's goroutine. If
+// Writer already implements WriteLevel (e.g. it is itself an *AsyncWriter shared with other LevelWriters),
+// Async is ignored and WriteLevel delegates to it directly.
 type LevelWriter struct {
 	io.Writer
-	Level Level
+	Level  Level
+	Async  bool
+	Buffer int
+	Policy Policy
+
+	async     atomic.Pointer[AsyncWriter]
+	asyncOnce sync.Once
 }
 
 // WriteLevel ...
 func (w *LevelWriter) WriteLevel(level Level, p []byte) (n int, err error) {
-	if level >= w.Level {
-		return w.Writer.Write(p)
+	if level < w.Level {
+		return len(p), nil
+	}
+
+	if lw, ok := w.Writer.(levelWriter); ok {
+		return lw.WriteLevel(level, p)
+	}
+
+	if w.Async {
+		w.asyncOnce.Do(func() {
+			w.async.Store(NewAsyncWriter(w.Writer, w.Buffer, w.Policy))
+		})
+		return w.async.Load().WriteLevel(level, p)
+	}
+	return w.Writer.Write(p)
+}
+
+// Close flushes and stops the writer's AsyncWriter, if Async is set or Writer is itself an *AsyncWriter; it
+// is a no-op otherwise.
+func (w *LevelWriter) Close(ctx context.Context) error {
+	if a := w.async.Load(); a != nil {
+		return a.Close(ctx)
+	}
+	if a, ok := w.Writer.(*AsyncWriter); ok {
+		return a.Close(ctx)
+	}
+	return nil
+}
+
+// Stats reports the AsyncWriter counters and queue depth for the writer's AsyncWriter, if Async is set or
+// Writer is itself an *AsyncWriter; the zero Stats otherwise.
+func (w *LevelWriter) Stats() Stats {
+	if a := w.async.Load(); a != nil {
+		return a.Stats()
+	}
+	if a, ok := w.Writer.(*AsyncWriter); ok {
+		return a.Stats()
 	}
-	return len(p), nil
+	return Stats{}
 }
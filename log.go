@@ -3,11 +3,12 @@ package log
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"runtime"
 	"strings"
 	"sync"
 	"sync/atomic"
-	"time"
 
 	"github.com/transientvariable/config-go"
 
@@ -27,18 +28,22 @@ const (
 )
 
 var (
-	defaultLogger atomic.Value
-	once          sync.Once
+	defaultHandler atomic.Pointer[Handler]
+	once           sync.Once
+
+	// osExit is os.Exit, indirected so tests can verify Fatal's termination behavior without killing the
+	// test binary.
+	osExit = os.Exit
 )
 
-// Logger defines the type for the logger.
+// Logger defines the type for a zerolog logger, kept for constructing or passing to NewZerologHandler.
 type Logger = zerolog.Logger
 
-// Init initializes the logging system and sets the default logger. If the default logger has already been set
-// (e.g. SetDefault), no further action is taken.
+// Init initializes the logging system and sets the default Handler. If the default Handler has already been
+// set (e.g. via SetDefault), no further action is taken.
 func Init() {
 	once.Do(func() {
-		if _, ok := defaultLogger.Load().(*Logger); ok {
+		if defaultHandler.Load() != nil {
 			return
 		}
 
@@ -49,13 +54,48 @@ func Init() {
 	})
 }
 
-// New creates a new logger using the provided Option properties.
-func New(options ...func(*Option)) *Logger {
+// New creates a new Handler using the provided Option properties. By default, the returned Handler is
+// backed by zerolog (see NewZerologHandler), configured per WithLevel; WithHandler overrides the backend
+// entirely, returning the supplied Handler as-is, so WithLevel does not apply in that case. WithVModule,
+// WithBacktraceAt, and WithOTel always apply regardless of backend, since per-package verbosity, backtrace
+// triggers, and OpenTelemetry trace correlation are all resolved by this package rather than by the Handler.
+// Like WithVModule and WithBacktraceAt, WithOTel only ever sets package-wide state when given; omitting it
+// from a later New() call does not turn a previously enabled behavior back off. Call SetOTel(false, false)
+// directly to do that.
+//
+// WithVModule, WithBacktraceAt, and WithOTel each take precedence over their log.vmodule/log.backtrace_at/
+// log.otel.enabled config key, so an operator can still diagnose a process started without them via config
+// alone, without a redeploy.
+func New(options ...func(*Option)) Handler {
 	opts := &Option{}
 	for _, opt := range options {
 		opt(opts)
 	}
 
+	if vmodule := optOrConfigString(opts.vmodule, VModule); vmodule != "" {
+		if err := SetVModule(vmodule); err != nil {
+			stdLog.Printf("log: %v", err)
+		}
+	}
+
+	if backtraceAt := optOrConfigString(opts.backtraceAt, BacktraceAt); backtraceAt != "" {
+		if err := SetBacktraceAt(backtraceAt); err != nil {
+			stdLog.Printf("log: %v", err)
+		}
+	}
+
+	otel, spanEvents := opts.otel, opts.otelSpanEvents
+	if !otel {
+		otel = boolValue(OTelEnabled, false)
+	}
+	if otel {
+		SetOTel(true, spanEvents)
+	}
+
+	if opts.handler != nil {
+		return opts.handler
+	}
+
 	logger := zlog.Output(zerolog.ConsoleWriter{Out: colorable.NewColorableStderr()}).With().Timestamp().Caller().Logger()
 	switch opts.level {
 	case "debug":
@@ -79,138 +119,180 @@ func New(options ...func(*Option)) *Logger {
 	default:
 		logger = logger.Level(LevelInfo)
 	}
-	return &logger
+
+	return NewZerologHandler(&logger)
 }
 
-// SetDefault sets the default logger used by all logging functions within the log package.
-func SetDefault(logger *Logger) error {
-	if logger == nil {
-		return errors.New("log: default logger cannot be nil")
+// SetDefault sets the default Handler used by all logging functions within the log package. h may be a
+// Handler, or a *zerolog.Logger for backward compatibility with code written against this package's
+// pre-Handler API; a *zerolog.Logger is wrapped via NewZerologHandler.
+//
+// SetDefault does not flush or close whatever the previous default Handler was writing through. A Handler
+// built from asynchronous file writers (see prepareFileWriters, LevelWriter.Async, and the log.file.async
+// config key) should have its *LevelWriter.Close(ctx) called explicitly by whoever constructed it before
+// discarding it, e.g. on process shutdown or just before replacing it via SetDefault.
+func SetDefault(h any) error {
+	if h == nil {
+		return errors.New("log: default handler cannot be nil")
+	}
+
+	var handler Handler
+	switch v := h.(type) {
+	case Handler:
+		handler = v
+	case *zerolog.Logger:
+		if v == nil {
+			return errors.New("log: default handler cannot be nil")
+		}
+		handler = NewZerologHandler(v)
+	default:
+		return fmt.Errorf("log: default handler must be a Handler or *zerolog.Logger, got %T", h)
+	}
+
+	if handler == nil {
+		return errors.New("log: default handler cannot be nil")
 	}
-	defaultLogger.Store(logger)
+
+	defaultHandler.Store(&handler)
 	return nil
 }
 
-// Default returns the default logger. If the default logger has not been initialized, Init is called before returning.
-func Default() *Logger {
+// Default returns the default Handler. If the default Handler has not been initialized, Init is called
+// before returning.
+func Default() Handler {
 	Init()
-	return defaultLogger.Load().(*Logger)
+	return *defaultHandler.Load()
 }
 
 // Debug records debug log event with the provided msg and arguments.
 func Debug(msg string, args ...func(*Record)) {
-	Log(LevelDebug, msg, args...)
+	LogDepth(1, LevelDebug, msg, args...)
 }
 
 // Error records and error log event on the default logger with the provided msg and arguments.
 func Error(msg string, args ...func(*Record)) {
-	Log(LevelError, msg, args...)
+	LogDepth(1, LevelError, msg, args...)
 }
 
-// Fatal records a fatal log event with the provided msg and arguments, then immediately terminates program execution
-// by calling os.Exit(1).
+// Fatal records a fatal log event with the provided msg and arguments, then immediately terminates program
+// execution by calling os.Exit(1). Termination happens regardless of which Handler backend is bound (see
+// WithHandler, SetDefault): it is enforced by handleEvent itself, not left to a backend's own side effects.
 func Fatal(msg string, args ...func(*Record)) {
-	Log(LevelFatal, msg, args...)
+	LogDepth(1, LevelFatal, msg, args...)
 }
 
 // Info calls info on the default logger.
 func Info(msg string, args ...func(*Record)) {
-	Log(LevelInfo, msg, args...)
+	LogDepth(1, LevelInfo, msg, args...)
 }
 
-// Panic calls panic on the default logger.
+// Panic records a panic log event with the provided msg and arguments, then panics with msg. Like Fatal, this
+// happens regardless of which Handler backend is bound.
 func Panic(msg string, args ...func(*Record)) {
-	Log(LevelPanic, msg, args...)
+	LogDepth(1, LevelPanic, msg, args...)
 }
 
 // Trace calls trace on the default logger.
 func Trace(msg string, args ...func(*Record)) {
-	Log(LevelTrace, msg, args...)
+	LogDepth(1, LevelTrace, msg, args...)
 }
 
 // Warn calls warn on the default logger.
 func Warn(msg string, args ...func(*Record)) {
-	Log(LevelWarn, msg, args...)
+	LogDepth(1, LevelWarn, msg, args...)
 }
 
 // Log records a log event using the provided Level.
 func Log(level Level, msg string, args ...func(*Record)) {
-	var event *zerolog.Event
-	switch level {
-	case LevelDebug:
-		lvl := Default().GetLevel()
-		if lvl == LevelDebug || lvl == LevelTrace {
-			event = Default().Debug()
+	LogDepth(1, level, msg, args...)
+}
+
+// LogDepth records a log event using the provided Level, attributing the call site skip frames above the
+// direct caller of LogDepth. It is exported so wrapper functions (e.g. Debug, Info, or a caller's own
+// helpers) can preserve correct file/line attribution by passing the number of additional frames they add.
+//
+// Before doing any other work, LogDepth checks the resolved caller against SetVModule. A file/package
+// demoted below level is rejected with nothing more than a cache lookup. One matching a rule that permits
+// level, conversely, must bypass the Handler's own Enabled check in handleEvent: SetVModule exists so a
+// single package can log more verbosely than the rest of the program, and a Handler configured at, say,
+// Info would otherwise silently swallow the Debug events vmodule just allowed. LogDepth cannot itself
+// reject a call as a fast path by comparing level against the default Handler's configured level, because
+// the event may carry a Context option resolving, via FromContext, to a different request-scoped Handler
+// (bound with NewContext) that is more permissive than the default; that resolution, and the Enabled check
+// against whichever Handler it yields (skipped entirely when vmodule already permitted the call), happens
+// in handleEvent once the Record exists.
+//
+// LogDepth also checks the resolved caller against SetBacktraceAt, so handleEvent can attach a stack
+// attribute holding a goroutine stack dump when it matches. The dump itself is deferred to handleEvent,
+// after its Enabled check, since capturing it is comparatively expensive and pointless for an event that
+// ends up discarded.
+func LogDepth(skip int, level Level, msg string, args ...func(*Record)) {
+	backtrace, allGoroutines, vmoduleOverride := false, false, false
+	if pc, file, line, ok := runtime.Caller(skip + 1); ok {
+		if effective, matched := vmoduleEffectiveLevel(pc); matched {
+			if level < effective {
+				return
+			}
+			vmoduleOverride = true
 		}
-		break
-	case LevelError:
-		event = Default().Error()
-		break
-	case LevelFatal:
-		event = Default().Fatal()
-		break
-	case LevelInfo:
-		event = Default().Info()
-		break
-	case LevelPanic:
-		event = Default().Panic()
-		break
-	case LevelTrace:
-		if Default().GetLevel() == LevelTrace {
-			event = Default().Trace()
+		if backtraceMatch(file, line) {
+			backtrace, allGoroutines = true, level >= LevelError
 		}
-		break
-	case LevelWarn:
-		event = Default().Warn()
-		break
-	default:
-		// no-op
-	}
-
-	if event != nil {
-		handleEvent(event, msg, args...)
 	}
+	handleEvent(callerSkipFrames+skip, level, msg, backtrace, allGoroutines, vmoduleOverride, args...)
 }
 
-func handleEvent(e *zerolog.Event, msg string, args ...func(*Record)) {
+// handleEvent always acquires a Record and applies args before checking the resolved Handler's Enabled,
+// since the Handler to check (see FromContext) cannot be known until any Context option among args has been
+// applied. This trades away the fast-path rejection a single package-wide level check would otherwise give
+// disabled levels, in exchange for per-request Handler overrides (see NewContext) being respected correctly.
+// vmoduleOverride, set by LogDepth when SetVModule explicitly permits this call site at level, skips the
+// Enabled check altogether; otherwise a Handler configured at a less verbose level than the vmodule rule
+// would drop the event vmodule just allowed through.
+//
+// handleEvent also enforces Fatal's and Panic's termination behavior itself, after handler.Handle returns,
+// rather than leaving it to a backend's own side effects: zerologHandler happens to terminate as a side
+// effect of calling zerolog.Logger.Fatal/Panic, but slogHandler, OTelHandler, and any other Handler have no
+// equivalent, which would otherwise make log.Fatal/log.Panic silently non-terminating under any backend but
+// the built-in one.
+func handleEvent(skip int, level Level, msg string, backtrace, allGoroutines, vmoduleOverride bool, args ...func(*Record)) {
 	r := acquireRecord()
 	defer releaseRecord(r)
 
 	for _, arg := range args {
 		arg(r)
 	}
+	r.skip = skip
 
-	e.CallerSkipFrame(callerSkipFrames)
-	e.Ctx(r.ctx)
-	e.Err(r.err)
-
-	for k, attr := range r.attrs {
-		switch attr.kind {
-		case kindAny:
-			e.Any(k, attr.value)
-		case kindBool:
-			e.Bool(k, attr.value.(bool))
-		case kindDuration:
-			e.Dur(k, attr.value.(time.Duration))
-		case kindFloat32:
-			e.Float32(k, attr.value.(float32))
-		case kindFloat64:
-			e.Float64(k, attr.value.(float64))
-		case kindInt64:
-			e.Int64(k, attr.value.(int64))
-		case kindString:
-			e.Str(k, attr.value.(string))
-		case kindTime:
-			e.Time(k, attr.value.(time.Time))
-		case kindUint64:
-			e.Uint64(k, attr.value.(uint64))
-		default:
-			// no-op
-		}
+	handler := FromContext(r.Ctx())
+	if !vmoduleOverride && !handler.Enabled(level) {
+		return
+	}
+
+	if backtrace {
+		r.addAttr("stack", dumpStack(allGoroutines), kindString)
+	}
+
+	enrichOTel(r, msg)
+	if err := handler.Handle(r.Ctx(), r, level, msg); err != nil {
+		stdLog.Printf("log: %v", err)
+	}
+
+	switch level {
+	case LevelFatal:
+		osExit(1)
+	case LevelPanic:
+		panic(msg)
 	}
-	e.Msg(msg)
 }
 
+// prepareFileWriters builds one LevelWriter per level, all sharing a lumberjack.Logger rolling writer for
+// file. Reading log.file.async, log.file.buffer, and log.file.policy makes the shared writer asynchronous:
+// a single AsyncWriter is built for file and given to every returned LevelWriter (see LevelWriter.WriteLevel),
+// rather than one per level, so the levels share one goroutine and buffer instead of racing N independent
+// ones over the same lumberjack.Logger. The caller owns the writers' lifecycle and is responsible for
+// calling LevelWriter.Close on (any) one of them before discarding them all, to flush anything still
+// buffered.
 func prepareFileWriters(file string, levels ...zerolog.Level) []*LevelWriter {
 	if len(levels) == 0 {
 		return nil
@@ -225,13 +307,58 @@ func prepareFileWriters(file string, levels ...zerolog.Level) []*LevelWriter {
 		MaxSize:    intValue(FileSize, defaultSize),
 	}
 
+	var sink io.Writer = lj
+	if boolValue(FileAsync, false) {
+		sink = NewAsyncWriter(lj, intValue(FileBuffer, defaultAsyncBuffer), policyValue(FilePolicy, DropOldest))
+	}
+
 	var w []*LevelWriter
 	for _, l := range levels {
-		w = append(w, &LevelWriter{Writer: lj, Level: l})
+		w = append(w, &LevelWriter{Writer: sink, Level: l})
 	}
 	return w
 }
 
+// optOrConfigString returns optValue if it is non-empty, falling back to config key path otherwise. It
+// gives an explicit Option (e.g. WithVModule) precedence over its config key (e.g. log.vmodule), the
+// convention New follows for every Option with a config-backed fallback.
+func optOrConfigString(optValue, path string) string {
+	if optValue != "" {
+		return optValue
+	}
+	return stringValue(path, "")
+}
+
+func boolValue(path string, defaultValue bool) bool {
+	if b, err := config.Bool(path); err == nil {
+		return b
+	}
+	return defaultValue
+}
+
+func policyValue(path string, defaultValue Policy) Policy {
+	switch strings.ToLower(strings.TrimSpace(stringValue(path, ""))) {
+	case "drop_oldest":
+		return DropOldest
+	case "drop_newest":
+		return DropNewest
+	case "block":
+		return Block
+	case "":
+		return defaultValue
+	default:
+		stdLog.Printf("log: unknown %s value, using default", path)
+		return defaultValue
+	}
+}
+
+func stringValue(path string, defaultValue string) string {
+	if s, err := config.String(path); err == nil && s != "" {
+		return s
+	}
+	return defaultValue
+}
+
 func prepareDir(path string) string {
 	dir := strings.TrimSpace(path)
 	if err := statDir(dir); err != nil {
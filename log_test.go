@@ -0,0 +1,59 @@
+package log
+
+import (
+	"context"
+	"testing"
+)
+
+// TestFatalTerminatesRegardlessOfHandlerBackend guards against Fatal's documented os.Exit(1) termination
+// being left to a Handler backend's own side effects: zerologHandler happens to terminate because it calls
+// zerolog.Logger.Fatal, but a Handler with no equivalent (e.g. a slog- or OTel-backed one) must still
+// terminate, since handleEvent enforces this itself.
+func TestFatalTerminatesRegardlessOfHandlerBackend(t *testing.T) {
+	var called bool
+	var code int
+
+	orig := osExit
+	osExit = func(c int) {
+		called, code = true, c
+		panic("osExit sentinel") // unwind back out of handleEvent without actually exiting the test binary
+	}
+	t.Cleanup(func() { osExit = orig })
+
+	h := &fakeHandler{enabledLevel: LevelInfo}
+	ctx := NewContext(context.Background(), h)
+
+	func() {
+		defer func() { _ = recover() }()
+		Fatal("boom", Context(ctx))
+	}()
+
+	if !called {
+		t.Fatal("osExit was not called")
+	}
+	if code != 1 {
+		t.Errorf("osExit code = %d, want 1", code)
+	}
+	if len(h.handled) != 1 || h.handled[0] != LevelFatal {
+		t.Errorf("handled = %v, want exactly one LevelFatal event", h.handled)
+	}
+}
+
+// TestPanicTerminatesRegardlessOfHandlerBackend is the Panic analogue of
+// TestFatalTerminatesRegardlessOfHandlerBackend.
+func TestPanicTerminatesRegardlessOfHandlerBackend(t *testing.T) {
+	h := &fakeHandler{enabledLevel: LevelInfo}
+	ctx := NewContext(context.Background(), h)
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("recover() = %v, want %q", r, "boom")
+		}
+		if len(h.handled) != 1 || h.handled[0] != LevelPanic {
+			t.Errorf("handled = %v, want exactly one LevelPanic event", h.handled)
+		}
+	}()
+
+	Panic("boom", Context(ctx))
+	t.Fatal("Panic did not panic")
+}
@@ -0,0 +1,224 @@
+// Package grpc provides server and client interceptors that emit a structured access-log record per RPC
+// and bind a request-scoped log.Handler into the call's context.
+package grpc
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	log "github.com/transientvariable/log-go"
+)
+
+// defaultMetadataKey is the metadata key used as the correlation ID unless WithMetadataKey overrides it.
+const defaultMetadataKey = "x-request-id"
+
+// Sampler reports whether an access-log record for a completed RPC should be emitted, given its resulting
+// code and duration. See WithSampler and SampleErrorsAlways.
+type Sampler func(code codes.Code, duration time.Duration) bool
+
+// SampleErrorsAlways returns a Sampler that always logs a non-codes.OK result, and otherwise logs
+// (roughly) 1-in-n of the rest, so a high-QPS service can bound access-log volume without losing error
+// visibility. n <= 1 logs every call.
+func SampleErrorsAlways(n int) Sampler {
+	var count atomic.Uint64
+	return func(code codes.Code, _ time.Duration) bool {
+		if code != codes.OK {
+			return true
+		}
+		if n <= 1 {
+			return true
+		}
+		return count.Add(1)%uint64(n) == 0
+	}
+}
+
+// Option configures UnaryServerInterceptor, StreamServerInterceptor, UnaryClientInterceptor, and
+// StreamClientInterceptor.
+type Option func(*options)
+
+type options struct {
+	handler     log.Handler
+	sampler     Sampler
+	metadataKey string
+}
+
+// WithHandler overrides the log.Handler the interceptors derive each call's child Handler from via
+// WithAttrs. By default, they derive from whatever Handler log.FromContext(ctx) already resolves to —
+// Default() if ctx carries none — so that composing these interceptors with an outer layer that already
+// bound a Handler (via log.NewContext, e.g. middleware/http on the way in) extends its attributes instead
+// of discarding them.
+func WithHandler(handler log.Handler) Option {
+	return func(o *options) {
+		o.handler = handler
+	}
+}
+
+// WithMetadataKey overrides the incoming/outgoing metadata key used to read and propagate the correlation
+// ID. The default is "x-request-id".
+func WithMetadataKey(key string) Option {
+	return func(o *options) {
+		if key != "" {
+			o.metadataKey = key
+		}
+	}
+}
+
+// WithSampler overrides which completed RPCs get an access-log record. The default, SampleErrorsAlways(1),
+// logs every call.
+func WithSampler(sampler Sampler) Option {
+	return func(o *options) {
+		o.sampler = sampler
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{sampler: SampleErrorsAlways(1), metadataKey: defaultMetadataKey}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// baseHandler returns the Handler an interceptor should derive its call-scoped Handler from via WithAttrs:
+// o.handler if WithHandler was given, otherwise whatever ctx already resolves to via log.FromContext.
+func baseHandler(ctx context.Context, o *options) log.Handler {
+	if o.handler != nil {
+		return o.handler
+	}
+	return log.FromContext(ctx)
+}
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor that logs one access-log record per call
+// (method, code, duration, peer) and binds a child log.Handler — carrying a correlation_id attribute read
+// from, or generated and echoed back through, the configured metadata key — into the handler's context,
+// retrievable via log.FromContext.
+func UnaryServerInterceptor(opts ...Option) ggrpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+	return func(ctx context.Context, req any, info *ggrpc.UnaryServerInfo, handler ggrpc.UnaryHandler) (any, error) {
+		id := incomingCorrelationID(ctx, o.metadataKey)
+		ctx = log.NewContext(ctx, baseHandler(ctx, o).WithAttrs(log.String("correlation_id", id)))
+		_ = ggrpc.SetHeader(ctx, metadata.Pairs(o.metadataKey, id))
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, o, info.FullMethod, time.Since(start), err)
+		return resp, err
+	}
+}
+
+// serverStream overrides ServerStream.Context so a handler wrapped by StreamServerInterceptor observes the
+// request-scoped context built by it rather than the original stream's.
+type serverStream struct {
+	ggrpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// StreamServerInterceptor returns a grpc.StreamServerInterceptor with the same behavior as
+// UnaryServerInterceptor, adapted for streaming RPCs.
+func StreamServerInterceptor(opts ...Option) ggrpc.StreamServerInterceptor {
+	o := newOptions(opts)
+	return func(srv any, ss ggrpc.ServerStream, info *ggrpc.StreamServerInfo, handler ggrpc.StreamHandler) error {
+		ctx := ss.Context()
+		id := incomingCorrelationID(ctx, o.metadataKey)
+		ctx = log.NewContext(ctx, baseHandler(ctx, o).WithAttrs(log.String("correlation_id", id)))
+		_ = ggrpc.SetHeader(ctx, metadata.Pairs(o.metadataKey, id))
+
+		start := time.Now()
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, o, info.FullMethod, time.Since(start), err)
+		return err
+	}
+}
+
+// UnaryClientInterceptor returns a grpc.UnaryClientInterceptor that logs one access-log record per call,
+// binds a child log.Handler carrying a correlation_id attribute into ctx (retrievable via log.FromContext
+// for the remainder of the caller's call chain), and propagates the same correlation ID through the
+// configured outgoing metadata key, generating one if ctx doesn't already carry one.
+func UnaryClientInterceptor(opts ...Option) ggrpc.UnaryClientInterceptor {
+	o := newOptions(opts)
+	return func(ctx context.Context, method string, req, resp any, cc *ggrpc.ClientConn, invoker ggrpc.UnaryInvoker, callOpts ...ggrpc.CallOption) error {
+		id := outgoingCorrelationID(ctx, o.metadataKey)
+		ctx = log.NewContext(ctx, baseHandler(ctx, o).WithAttrs(log.String("correlation_id", id)))
+		ctx = metadata.AppendToOutgoingContext(ctx, o.metadataKey, id)
+
+		start := time.Now()
+		err := invoker(ctx, method, req, resp, cc, callOpts...)
+		logCall(ctx, o, method, time.Since(start), err)
+		return err
+	}
+}
+
+// StreamClientInterceptor returns a grpc.StreamClientInterceptor with the same correlation-ID binding and
+// propagation as UnaryClientInterceptor, adapted for streaming RPCs; since a streaming call's outcome isn't
+// known until the stream closes, the access-log record it emits reflects the time to establish the stream,
+// not its full lifetime.
+func StreamClientInterceptor(opts ...Option) ggrpc.StreamClientInterceptor {
+	o := newOptions(opts)
+	return func(ctx context.Context, desc *ggrpc.StreamDesc, cc *ggrpc.ClientConn, method string, streamer ggrpc.Streamer, callOpts ...ggrpc.CallOption) (ggrpc.ClientStream, error) {
+		id := outgoingCorrelationID(ctx, o.metadataKey)
+		ctx = log.NewContext(ctx, baseHandler(ctx, o).WithAttrs(log.String("correlation_id", id)))
+		ctx = metadata.AppendToOutgoingContext(ctx, o.metadataKey, id)
+
+		start := time.Now()
+		cs, err := streamer(ctx, desc, cc, method, callOpts...)
+		logCall(ctx, o, method, time.Since(start), err)
+		return cs, err
+	}
+}
+
+func incomingCorrelationID(ctx context.Context, key string) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(key); len(v) > 0 && v[0] != "" {
+			return v[0]
+		}
+	}
+	return uuid.NewString()
+}
+
+func outgoingCorrelationID(ctx context.Context, key string) string {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		if v := md.Get(key); len(v) > 0 && v[0] != "" {
+			return v[0]
+		}
+	}
+	return uuid.NewString()
+}
+
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+func logCall(ctx context.Context, o *options, method string, duration time.Duration, err error) {
+	code := status.Code(err)
+	if !o.sampler(code, duration) {
+		return
+	}
+
+	level := log.LevelInfo
+	if code != codes.OK {
+		level = log.LevelError
+	}
+
+	log.LogDepth(1, level, "grpc: call",
+		log.Context(ctx),
+		log.String("method", method),
+		log.String("code", code.String()),
+		log.Duration("duration", duration),
+		log.String("peer", peerAddr(ctx)),
+		log.Err(err))
+}
@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestIncomingCorrelationIDPrefersMetadata(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(defaultMetadataKey, "from-metadata"))
+
+	if got := incomingCorrelationID(ctx, defaultMetadataKey); got != "from-metadata" {
+		t.Errorf("incomingCorrelationID = %q, want %q", got, "from-metadata")
+	}
+}
+
+func TestIncomingCorrelationIDGeneratesUUIDWhenAbsent(t *testing.T) {
+	id := incomingCorrelationID(context.Background(), defaultMetadataKey)
+	if id == "" {
+		t.Fatal("incomingCorrelationID returned an empty string")
+	}
+	if id2 := incomingCorrelationID(context.Background(), defaultMetadataKey); id2 == id {
+		t.Error("incomingCorrelationID returned the same value for two independent calls with no metadata set")
+	}
+}
+
+func TestOutgoingCorrelationIDPrefersMetadata(t *testing.T) {
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs(defaultMetadataKey, "from-metadata"))
+
+	if got := outgoingCorrelationID(ctx, defaultMetadataKey); got != "from-metadata" {
+		t.Errorf("outgoingCorrelationID = %q, want %q", got, "from-metadata")
+	}
+}
+
+func TestOutgoingCorrelationIDGeneratesUUIDWhenAbsent(t *testing.T) {
+	id := outgoingCorrelationID(context.Background(), defaultMetadataKey)
+	if id == "" {
+		t.Fatal("outgoingCorrelationID returned an empty string")
+	}
+}
+
+func TestSampleErrorsAlways(t *testing.T) {
+	t.Run("n<=1 logs everything", func(t *testing.T) {
+		s := SampleErrorsAlways(1)
+		for i := 0; i < 5; i++ {
+			if !s(codes.OK, 0) {
+				t.Fatalf("call %d = false, want true", i)
+			}
+		}
+	})
+
+	t.Run("non-OK code is always logged regardless of n", func(t *testing.T) {
+		s := SampleErrorsAlways(100)
+		if !s(codes.Internal, 0) {
+			t.Error("want true for a non-OK code even with a large sample interval")
+		}
+	})
+
+	t.Run("OK results are sampled roughly 1-in-n", func(t *testing.T) {
+		s := SampleErrorsAlways(3)
+		want := []bool{false, false, true, false, false, true}
+		for i, w := range want {
+			if got := s(codes.OK, 0); got != w {
+				t.Errorf("call %d = %v, want %v", i, got, w)
+			}
+		}
+	})
+}
+
+func TestWithMetadataKeyIgnoresEmpty(t *testing.T) {
+	o := newOptions([]Option{WithMetadataKey("")})
+	if o.metadataKey != defaultMetadataKey {
+		t.Errorf("metadataKey = %q, want default %q after WithMetadataKey(\"\")", o.metadataKey, defaultMetadataKey)
+	}
+
+	o = newOptions([]Option{WithMetadataKey("x-correlation-id")})
+	if o.metadataKey != "x-correlation-id" {
+		t.Errorf("metadataKey = %q, want %q", o.metadataKey, "x-correlation-id")
+	}
+}
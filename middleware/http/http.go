@@ -0,0 +1,183 @@
+// Package http provides middleware that wraps an http.Handler to emit a structured access-log record per
+// request and bind a request-scoped log.Handler into the request's context.
+package http
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	log "github.com/transientvariable/log-go"
+)
+
+// responseRecorder captures the status code and byte count written through an http.ResponseWriter so New's
+// handler can include them in its access-log record after the wrapped handler returns.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// Flush implements http.Flusher by delegating to the wrapped ResponseWriter, so handlers that stream
+// responses (e.g. Server-Sent Events) still work when served through New.
+func (r *responseRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker by delegating to the wrapped ResponseWriter, so handlers that take over
+// the connection (e.g. WebSocket upgrades) still work when served through New.
+func (r *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("http: ResponseWriter does not support Hijack: %T", r.ResponseWriter)
+	}
+	return h.Hijack()
+}
+
+// Sampler reports whether an access-log record for a completed request should be emitted, given its status
+// and duration. See WithSampler and SampleErrorsAlways.
+type Sampler func(status int, duration time.Duration) bool
+
+// SampleErrorsAlways returns a Sampler that always logs a request whose status is >= 500, and otherwise
+// logs (roughly) 1-in-n of the rest, so a high-QPS service can bound access-log volume without losing error
+// visibility. n <= 1 logs every request.
+func SampleErrorsAlways(n int) Sampler {
+	var count atomic.Uint64
+	return func(status int, _ time.Duration) bool {
+		if status >= http.StatusInternalServerError {
+			return true
+		}
+		if n <= 1 {
+			return true
+		}
+		return count.Add(1)%uint64(n) == 0
+	}
+}
+
+// Option configures New.
+type Option func(*options)
+
+type options struct {
+	handler         log.Handler
+	requestIDHeader string
+	sampler         Sampler
+}
+
+// WithHandler overrides the log.Handler New derives each request's child Handler from via WithAttrs. By
+// default, New derives from whatever Handler log.FromContext(req.Context()) already resolves to — Default()
+// if the request carries none — so that composing New with an outer middleware layer that already bound a
+// Handler (via log.NewContext, e.g. another instance of this middleware, or middleware/grpc on the way in)
+// extends its attributes instead of discarding them.
+func WithHandler(handler log.Handler) Option {
+	return func(o *options) {
+		o.handler = handler
+	}
+}
+
+// WithRequestIDHeader overrides the header New reads an incoming request ID from, in preference to the
+// traceparent header, and always echoes the request ID back on. The default is X-Request-ID.
+func WithRequestIDHeader(name string) Option {
+	return func(o *options) {
+		if name = strings.TrimSpace(name); name != "" {
+			o.requestIDHeader = name
+		}
+	}
+}
+
+// WithSampler overrides which completed requests get an access-log record. The default, SampleErrorsAlways(1),
+// logs every request.
+func WithSampler(sampler Sampler) Option {
+	return func(o *options) {
+		o.sampler = sampler
+	}
+}
+
+// New wraps next with middleware that logs one access-log record per request (method, path, status, bytes
+// written, and duration) and binds a child log.Handler — carrying a request_id correlation attribute — into
+// the request's context, retrievable via log.FromContext within next and everything it calls.
+//
+// The request ID is taken from the incoming WithRequestIDHeader header if present, falling back to the W3C
+// traceparent header's trace ID, falling back to a newly generated UUID; it is always echoed back to the
+// caller via WithRequestIDHeader.
+func New(next http.Handler, opts ...Option) http.Handler {
+	o := &options{
+		requestIDHeader: "X-Request-ID",
+		sampler:         SampleErrorsAlways(1),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id := requestID(req, o.requestIDHeader)
+		w.Header().Set(o.requestIDHeader, id)
+
+		handler := o.handler
+		if handler == nil {
+			handler = log.FromContext(req.Context())
+		}
+		ctx := log.NewContext(req.Context(), handler.WithAttrs(log.String("request_id", id)))
+		req = req.WithContext(ctx)
+
+		rec := &responseRecorder{ResponseWriter: w}
+		start := time.Now()
+		next.ServeHTTP(rec, req)
+		duration := time.Since(start)
+
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+		if !o.sampler(rec.status, duration) {
+			return
+		}
+
+		level := log.LevelInfo
+		if rec.status >= http.StatusInternalServerError {
+			level = log.LevelError
+		}
+
+		log.LogDepth(1, level, "http: request",
+			log.Context(ctx),
+			log.String("method", req.Method),
+			log.String("path", req.URL.Path),
+			log.Int("status", rec.status),
+			log.Int("bytes", rec.bytes),
+			log.Duration("duration", duration),
+			log.String("request_id", id))
+	})
+}
+
+func requestID(req *http.Request, header string) string {
+	if id := strings.TrimSpace(req.Header.Get(header)); id != "" {
+		return id
+	}
+
+	// W3C traceparent: version-traceid-spanid-flags.
+	if tp := strings.Split(strings.TrimSpace(req.Header.Get("traceparent")), "-"); len(tp) >= 2 && tp[1] != "" {
+		return tp[1]
+	}
+
+	return uuid.NewString()
+}
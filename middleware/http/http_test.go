@@ -0,0 +1,157 @@
+package http
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestIDPrefersHeaderOverTraceparent(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Request-ID", "from-header")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	if got := requestID(req, "X-Request-ID"); got != "from-header" {
+		t.Errorf("requestID = %q, want %q", got, "from-header")
+	}
+}
+
+func TestRequestIDFallsBackToTraceparentTraceID(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	want := "4bf92f3577b34da6a3ce929d0e0e4736"
+	if got := requestID(req, "X-Request-ID"); got != want {
+		t.Errorf("requestID = %q, want %q", got, want)
+	}
+}
+
+func TestRequestIDGeneratesUUIDAsLastResort(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	id := requestID(req, "X-Request-ID")
+	if id == "" {
+		t.Fatal("requestID returned an empty string")
+	}
+	if id2 := requestID(req, "X-Request-ID"); id2 == id {
+		t.Error("requestID returned the same value for two independent calls with no header set")
+	}
+}
+
+func TestSampleErrorsAlways(t *testing.T) {
+	t.Run("n<=1 logs everything", func(t *testing.T) {
+		s := SampleErrorsAlways(1)
+		for i := 0; i < 5; i++ {
+			if !s(http.StatusOK, 0) {
+				t.Fatalf("call %d = false, want true", i)
+			}
+		}
+	})
+
+	t.Run("5xx is always logged regardless of n", func(t *testing.T) {
+		s := SampleErrorsAlways(100)
+		if !s(http.StatusInternalServerError, 0) {
+			t.Error("want true for a 5xx status even with a large sample interval")
+		}
+	})
+
+	t.Run("non-errors are sampled roughly 1-in-n", func(t *testing.T) {
+		s := SampleErrorsAlways(3)
+		want := []bool{false, false, true, false, false, true}
+		for i, w := range want {
+			if got := s(http.StatusOK, 0); got != w {
+				t.Errorf("call %d = %v, want %v", i, got, w)
+			}
+		}
+	})
+}
+
+// plainResponseWriter implements only http.ResponseWriter, neither http.Flusher nor http.Hijacker, so tests
+// can exercise responseRecorder's passthrough methods against a ResponseWriter that genuinely doesn't
+// support them.
+type plainResponseWriter struct {
+	header http.Header
+}
+
+func (w *plainResponseWriter) Header() http.Header        { return w.header }
+func (w *plainResponseWriter) Write(p []byte) (int, error) { return len(p), nil }
+func (w *plainResponseWriter) WriteHeader(int)             {}
+
+// hijackableWriter wraps httptest.ResponseRecorder (which implements http.Flusher but not http.Hijacker)
+// and adds a Hijack that just records it was called.
+type hijackableWriter struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+}
+
+func (w *hijackableWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.hijacked = true
+	return nil, nil, nil
+}
+
+func TestResponseRecorderFlushDelegatesWhenSupported(t *testing.T) {
+	inner := httptest.NewRecorder()
+	rec := &responseRecorder{ResponseWriter: inner}
+
+	rec.Flush()
+
+	if !inner.Flushed {
+		t.Error("Flush did not delegate to the wrapped ResponseWriter")
+	}
+}
+
+func TestResponseRecorderFlushNoopWhenUnsupported(t *testing.T) {
+	rec := &responseRecorder{ResponseWriter: &plainResponseWriter{header: http.Header{}}}
+	rec.Flush() // must not panic
+}
+
+func TestResponseRecorderHijackDelegatesWhenSupported(t *testing.T) {
+	inner := &hijackableWriter{ResponseRecorder: httptest.NewRecorder()}
+	rec := &responseRecorder{ResponseWriter: inner}
+
+	if _, _, err := rec.Hijack(); err != nil {
+		t.Fatalf("Hijack: %v", err)
+	}
+	if !inner.hijacked {
+		t.Error("Hijack did not delegate to the wrapped ResponseWriter")
+	}
+}
+
+func TestResponseRecorderHijackErrorsWhenUnsupported(t *testing.T) {
+	rec := &responseRecorder{ResponseWriter: &plainResponseWriter{header: http.Header{}}}
+
+	if _, _, err := rec.Hijack(); err == nil {
+		t.Error("Hijack returned a nil error for a ResponseWriter that does not implement http.Hijacker")
+	}
+}
+
+func TestResponseRecorderTracksStatusAndBytes(t *testing.T) {
+	rec := &responseRecorder{ResponseWriter: httptest.NewRecorder()}
+
+	rec.WriteHeader(http.StatusTeapot)
+	n, err := rec.Write([]byte("hello"))
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if rec.status != http.StatusTeapot {
+		t.Errorf("status = %d, want %d", rec.status, http.StatusTeapot)
+	}
+	if n != 5 || rec.bytes != 5 {
+		t.Errorf("Write returned n=%d, rec.bytes=%d, want 5 and 5", n, rec.bytes)
+	}
+}
+
+func TestResponseRecorderDefaultsStatusToOKOnFirstWrite(t *testing.T) {
+	rec := &responseRecorder{ResponseWriter: httptest.NewRecorder()}
+
+	if _, err := rec.Write([]byte("hi")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if rec.status != http.StatusOK {
+		t.Errorf("status = %d, want %d (default when WriteHeader was never called)", rec.status, http.StatusOK)
+	}
+}
@@ -4,7 +4,12 @@ import "strings"
 
 // Option is a container for optional properties that can be used for initializing the logging system.
 type Option struct {
-	level string
+	level          string
+	vmodule        string
+	backtraceAt    string
+	handler        Handler
+	otel           bool
+	otelSpanEvents bool
 }
 
 // WithLevel sets the logging level Option. The default logging level is info.
@@ -13,3 +18,41 @@ func WithLevel(level string) func(*Option) {
 		o.level = strings.TrimSpace(level)
 	}
 }
+
+// WithVModule sets the per-file/per-package verbosity Option, applied via SetVModule when New is called.
+// See SetVModule for the spec format.
+func WithVModule(spec string) func(*Option) {
+	return func(o *Option) {
+		o.vmodule = strings.TrimSpace(spec)
+	}
+}
+
+// WithBacktraceAt sets the backtrace-trigger Option, applied via SetBacktraceAt when New is called. See
+// SetBacktraceAt for the spec format.
+func WithBacktraceAt(spec string) func(*Option) {
+	return func(o *Option) {
+		o.backtraceAt = strings.TrimSpace(spec)
+	}
+}
+
+// WithHandler overrides the Handler backend New constructs, so a program can plug in a Handler other than
+// the built-in zerolog-backed implementation (e.g. one returned by NewSlogHandler, or a Tee of several).
+// WithLevel does not apply to a Handler supplied this way; WithVModule, WithBacktraceAt, and WithOTel still
+// apply, since per-package verbosity, backtrace triggers, and trace correlation are resolved by the package,
+// not by the Handler.
+func WithHandler(handler Handler) func(*Option) {
+	return func(o *Option) {
+		o.handler = handler
+	}
+}
+
+// WithOTel enables automatic OpenTelemetry trace correlation, applied via SetOTel when New is called: every
+// event emitted afterward, through any Handler, has trace_id, span_id, and trace_flags attributes attached
+// from the active trace.SpanContext in its Record's context, if any. If spanEvents is true, the event is
+// additionally mirrored onto that span as a span event. See SetOTel.
+func WithOTel(spanEvents bool) func(*Option) {
+	return func(o *Option) {
+		o.otel = true
+		o.otelSpanEvents = spanEvents
+	}
+}
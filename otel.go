@@ -0,0 +1,96 @@
+package log
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	otelEnabled    atomic.Bool
+	otelSpanEvents atomic.Bool
+)
+
+// SetOTel enables or disables automatic OpenTelemetry trace correlation package-wide: once enabled, every
+// event subsequently emitted through LogDepth (and so Debug, Info, Log, ...) has trace_id, span_id, and
+// trace_flags attributes attached, extracted from the trace.SpanContext active in the event's Record
+// context (see Context). If spanEvents is true, the event is additionally mirrored onto that span as a span
+// event via span.AddEvent, carrying the event's attributes. SetOTel is safe for concurrent use; New applies
+// it when WithOTel is given.
+func SetOTel(enabled, spanEvents bool) {
+	otelEnabled.Store(enabled)
+	otelSpanEvents.Store(spanEvents)
+}
+
+// enrichOTel attaches trace correlation attributes to r, and mirrors msg onto the active span as a span
+// event, if SetOTel/WithOTel has enabled either behavior. It is a no-op if r's context carries no valid
+// trace.SpanContext (as with context.Background()); whether the span is sampled is not consulted.
+func enrichOTel(r *Record, msg string) {
+	if !otelEnabled.Load() {
+		return
+	}
+
+	span := trace.SpanFromContext(r.Ctx())
+	sc := span.SpanContext()
+	if !sc.IsValid() {
+		return
+	}
+
+	r.addAttr("trace_id", sc.TraceID().String(), kindString)
+	r.addAttr("span_id", sc.SpanID().String(), kindString)
+	r.addAttr("trace_flags", sc.TraceFlags().String(), kindString)
+
+	if otelSpanEvents.Load() {
+		var kvs []attribute.KeyValue
+		if err := r.Err(); err != nil {
+			kvs = append(kvs, attribute.String("error", err.Error()))
+		}
+		r.Attrs(func(key string, kind Kind, value any) {
+			kvs = append(kvs, toOTelAttr(key, kind, value))
+		})
+		span.AddEvent(msg, trace.WithAttributes(kvs...))
+	}
+}
+
+// decodeAttr converts a Record attribute's Kind-tagged value (see the Kind doc comment) to a bool, int64,
+// float64, or string: Float32/Float64 decode to float64, Duration (as nanoseconds) and Uint64 convert to
+// int64, Time formats as an RFC 3339 string, and Any formats via fmt.Sprintf. It exists so the OTLP log
+// (handler_otel.go) and span-event (enrichOTel) conversions decode bit-packed Duration/Float32/Float64
+// attributes identically instead of drifting apart.
+func decodeAttr(kind Kind, value any) any {
+	switch kind {
+	case KindBool:
+		return value.(bool)
+	case KindDuration, KindUint64:
+		return int64(value.(uint64))
+	case KindFloat32:
+		return float64(math.Float32frombits(value.(uint32)))
+	case KindFloat64:
+		return math.Float64frombits(value.(uint64))
+	case KindInt64:
+		return value.(int64)
+	case KindString:
+		return value.(string)
+	case KindTime:
+		return value.(time.Time).Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+func toOTelAttr(key string, kind Kind, value any) attribute.KeyValue {
+	switch v := decodeAttr(kind, value).(type) {
+	case bool:
+		return attribute.Bool(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case float64:
+		return attribute.Float64(key, v)
+	default:
+		return attribute.String(key, fmt.Sprintf("%v", v))
+	}
+}
@@ -0,0 +1,133 @@
+package log
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TestDecodeAttrRoundTripsAllKinds covers decodeAttr for every Kind, since both the OTel log conversion
+// (handler_otel.go's toOTelLogAttr) and the span-event conversion (toOTelAttr, below) depend on it decoding
+// bit-packed Duration/Float32/Float64 attributes back to their natural Go type identically.
+func TestDecodeAttrRoundTripsAllKinds(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name  string
+		kind  Kind
+		value any
+		want  any
+	}{
+		{name: "bool", kind: KindBool, value: true, want: true},
+		{name: "duration", kind: KindDuration, value: uint64(5 * time.Second), want: int64(5 * time.Second)},
+		{name: "float32", kind: KindFloat32, value: math.Float32bits(1.5), want: float64(1.5)},
+		{name: "float64", kind: KindFloat64, value: math.Float64bits(2.5), want: float64(2.5)},
+		{name: "int64", kind: KindInt64, value: int64(42), want: int64(42)},
+		{name: "string", kind: KindString, value: "hi", want: "hi"},
+		{name: "time", kind: KindTime, value: now, want: now.Format(time.RFC3339Nano)},
+		{name: "uint64", kind: KindUint64, value: uint64(7), want: int64(7)},
+		{name: "any falls back to fmt.Sprintf", kind: KindAny, value: 123, want: "123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decodeAttr(tt.kind, tt.value); got != tt.want {
+				t.Errorf("decodeAttr(%v, %v) = %v (%T), want %v (%T)", tt.kind, tt.value, got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnrichOTelNoopWhenDisabled(t *testing.T) {
+	SetOTel(false, false)
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample()))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "op")
+	defer span.End()
+
+	r := acquireRecord()
+	defer releaseRecord(r)
+	r.ctx = ctx
+
+	enrichOTel(r, "msg")
+
+	var n int
+	r.Attrs(func(string, Kind, any) { n++ })
+	if n != 0 {
+		t.Errorf("enrichOTel added %d attrs while disabled, want 0", n)
+	}
+}
+
+// TestEnrichOTelAttachesTraceAttributesWhenEnabled covers SetOTel(true, false): every event gets trace_id,
+// span_id, and trace_flags attributes extracted from the active span, without mirroring onto it.
+func TestEnrichOTelAttachesTraceAttributesWhenEnabled(t *testing.T) {
+	SetOTel(true, false)
+	t.Cleanup(func() { SetOTel(false, false) })
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample()))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "op")
+	defer span.End()
+
+	r := acquireRecord()
+	defer releaseRecord(r)
+	r.ctx = ctx
+
+	enrichOTel(r, "msg")
+
+	got := map[string]any{}
+	r.Attrs(func(key string, _ Kind, value any) { got[key] = value })
+
+	sc := trace.SpanContextFromContext(ctx)
+	if got["trace_id"] != sc.TraceID().String() {
+		t.Errorf("trace_id = %v, want %v", got["trace_id"], sc.TraceID().String())
+	}
+	if got["span_id"] != sc.SpanID().String() {
+		t.Errorf("span_id = %v, want %v", got["span_id"], sc.SpanID().String())
+	}
+	if got["trace_flags"] != sc.TraceFlags().String() {
+		t.Errorf("trace_flags = %v, want %v", got["trace_flags"], sc.TraceFlags().String())
+	}
+}
+
+// TestEnrichOTelMirrorsSpanEventWhenConfigured covers SetOTel(true, true): the event is additionally mirrored
+// onto the active span as a span event carrying the Record's attributes.
+func TestEnrichOTelMirrorsSpanEventWhenConfigured(t *testing.T) {
+	SetOTel(true, true)
+	t.Cleanup(func() { SetOTel(false, false) })
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample()), sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "op")
+
+	r := acquireRecord()
+	r.ctx = ctx
+	r.addAttr("key", "value", kindString)
+
+	enrichOTel(r, "event-msg")
+	releaseRecord(r)
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d exported spans, want 1", len(spans))
+	}
+
+	events := spans[0].Events
+	if len(events) != 1 {
+		t.Fatalf("got %d span events, want 1", len(events))
+	}
+	if events[0].Name != "event-msg" {
+		t.Errorf("event name = %q, want %q", events[0].Name, "event-msg")
+	}
+}
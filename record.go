@@ -22,6 +22,24 @@ const (
 	kindUint64
 )
 
+// Kind identifies how an attribute's value must be interpreted by a Handler. Most kinds store their value
+// using its natural Go type, but Duration, Float32, and Float64 are stored as the underlying bit pattern
+// (via time.Duration.Nanoseconds and math.Float32/64bits) to avoid boxing allocations on the hot path, so a
+// Handler must switch on Kind to decode them back to their natural type.
+type Kind = kind
+
+const (
+	KindAny      = kindAny
+	KindBool     = kindBool
+	KindDuration = kindDuration
+	KindFloat32  = kindFloat32
+	KindFloat64  = kindFloat64
+	KindInt64    = kindInt64
+	KindString   = kindString
+	KindTime     = kindTime
+	KindUint64   = kindUint64
+)
+
 var recPool = sync.Pool{
 	New: func() any {
 		return &Record{}
@@ -38,6 +56,7 @@ func releaseRecord(r *Record) {
 		r.ctx = nil
 		r.err = nil
 		r.msg = ""
+		r.skip = 0
 		recPool.Put(r)
 	}
 }
@@ -53,6 +72,7 @@ type Record struct {
 	ctx   context.Context
 	err   error
 	msg   string
+	skip  int
 }
 
 func (r *Record) addAttr(key string, value any, kind kind) {
@@ -149,3 +169,33 @@ func Uint64(key string, value uint64) func(*Record) {
 		r.addAttr(key, value, kindUint64)
 	}
 }
+
+// Attrs invokes fn for each attribute set on the Record, in no particular order, passing the attribute's
+// key, Kind, and stored value. It is intended for Handler implementations living outside this package,
+// which cannot otherwise reach the Record's unexported fields.
+func (r *Record) Attrs(fn func(key string, kind Kind, value any)) {
+	for k, a := range r.attrs {
+		fn(k, a.kind, a.value)
+	}
+}
+
+// Err returns the error attribute set on the Record via the Err option, if any.
+func (r *Record) Err() error {
+	return r.err
+}
+
+// Skip returns the number of additional stack frames a Handler must skip, on top of its own call depth,
+// to attribute a log event to its original call site (e.g. via zerolog.Event.CallerSkipFrame). It is set
+// by LogDepth before the Record reaches a Handler.
+func (r *Record) Skip() int {
+	return r.skip
+}
+
+// Ctx returns the context.Context set on the Record via the Context option, defaulting to
+// context.Background() if none was set.
+func (r *Record) Ctx() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+	return r.ctx
+}
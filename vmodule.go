@@ -0,0 +1,169 @@
+package log
+
+import (
+	"fmt"
+	"path"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+// vmoduleRule is a single pattern=level entry parsed from a vmodule spec.
+type vmoduleRule struct {
+	pattern string
+	level   Level
+}
+
+// vmoduleEntry is the cached resolution for a given program counter, tagged with the rule generation it
+// was resolved against so a SetVModule call invalidates stale entries without a full cache sweep.
+type vmoduleEntry struct {
+	generation uint64
+	level      Level
+	matched    bool
+}
+
+var (
+	vmoduleRules      atomic.Value // []vmoduleRule
+	vmoduleGeneration atomic.Uint64
+	vmoduleCache      sync.Map // map[uintptr]vmoduleEntry
+)
+
+// SetVModule configures per-file/per-package verbosity overrides from a comma-separated list of
+// pattern=level entries, e.g.:
+//
+//	mypkg/*=debug,internal/auth/token.go=trace,github.com/acme/foo=warn
+//
+// Each pattern is matched, in order, against the emitting call site's file path using glob syntax
+// (path.Match), tried both against the full, absolute file path and against its trailing path segments (as
+// many as pattern itself has, so "internal/auth/token.go" matches regardless of where the build placed the
+// source tree); failing that, it falls back to a suffix match against the call site's package import path.
+// The first matching entry wins. Passing an empty spec clears all overrides. SetVModule is safe for
+// concurrent use and takes effect for subsequent log calls; cached resolutions are invalidated via a
+// generation counter rather than cleared eagerly.
+func SetVModule(spec string) error {
+	var rules []vmoduleRule
+	spec = strings.TrimSpace(spec)
+	if spec != "" {
+		for _, entry := range strings.Split(spec, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+
+			parts := strings.SplitN(entry, "=", 2)
+			if len(parts) != 2 {
+				return fmt.Errorf("log: invalid vmodule entry: %s", entry)
+			}
+
+			level, err := zerolog.ParseLevel(strings.TrimSpace(parts[1]))
+			if err != nil {
+				return fmt.Errorf("log: invalid vmodule level: %s: %w", entry, err)
+			}
+
+			pattern := strings.TrimSpace(parts[0])
+			if pattern == "" {
+				return fmt.Errorf("log: invalid vmodule pattern: %s", entry)
+			}
+
+			rules = append(rules, vmoduleRule{pattern: pattern, level: level})
+		}
+	}
+
+	vmoduleRules.Store(rules)
+	vmoduleGeneration.Add(1)
+	return nil
+}
+
+// V reports whether logging at the given level is enabled for the caller's file/package, consulting any
+// SetVModule override before falling back to the default logger's level. It is intended to guard
+// expensive argument computation at call sites, e.g.:
+//
+//	if log.V(log.LevelDebug) {
+//		log.Debug("state", log.Any("snapshot", expensiveSnapshot()))
+//	}
+func V(level Level) bool {
+	pc, _, _, ok := runtime.Caller(1)
+	if !ok {
+		return Default().Enabled(level)
+	}
+
+	if effective, matched := vmoduleEffectiveLevel(pc); matched {
+		return level >= effective
+	}
+	return Default().Enabled(level)
+}
+
+// vmoduleEffectiveLevel returns the effective level for the call site at pc and whether a vmodule rule
+// matched it. Lookups are cached keyed by pc so the steady-state cost is a single map read; the cache is
+// invalidated lazily by comparing against the current rule generation.
+func vmoduleEffectiveLevel(pc uintptr) (Level, bool) {
+	gen := vmoduleGeneration.Load()
+
+	if v, ok := vmoduleCache.Load(pc); ok {
+		entry := v.(vmoduleEntry)
+		if entry.generation == gen {
+			return entry.level, entry.matched
+		}
+	}
+
+	level, matched := resolveVModule(pc)
+	vmoduleCache.Store(pc, vmoduleEntry{generation: gen, level: level, matched: matched})
+	return level, matched
+}
+
+func resolveVModule(pc uintptr) (Level, bool) {
+	rules, _ := vmoduleRules.Load().([]vmoduleRule)
+	if len(rules) == 0 {
+		return LevelNone, false
+	}
+
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return LevelNone, false
+	}
+
+	file, _ := fn.FileLine(pc)
+	pkg := fn.Name()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		if j := strings.IndexByte(pkg[i+1:], '.'); j >= 0 {
+			pkg = pkg[:i+1+j]
+		}
+	} else if j := strings.IndexByte(pkg, '.'); j >= 0 {
+		pkg = pkg[:j]
+	}
+
+	for _, r := range rules {
+		if fileMatch(r.pattern, file) {
+			return r.level, true
+		}
+		if pkg == r.pattern || strings.HasSuffix(pkg, "/"+r.pattern) {
+			return r.level, true
+		}
+	}
+	return LevelNone, false
+}
+
+// fileMatch reports whether pattern matches file, which is always an absolute build path (runtime.Caller's
+// file, via fn.FileLine), while pattern is typically written relative to the source tree, e.g.
+// "internal/auth/token.go" or "mypkg/*". path.Match against the full file essentially never matches such a
+// pattern, since path.Match requires the whole string to match and its "*" never crosses "/". fileMatch
+// works around this the same way backtraceMatch does for plain paths: it also tries pattern against the
+// trailing path segments of file, taking as many segments as pattern itself has, so a relative pattern
+// matches as a path suffix regardless of where the full build path places the source tree.
+func fileMatch(pattern, file string) bool {
+	if ok, _ := path.Match(pattern, file); ok {
+		return true
+	}
+
+	segments := strings.Split(file, "/")
+	n := strings.Count(pattern, "/") + 1
+	if n > len(segments) {
+		return false
+	}
+
+	ok, _ := path.Match(pattern, strings.Join(segments[len(segments)-n:], "/"))
+	return ok
+}
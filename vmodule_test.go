@@ -0,0 +1,103 @@
+package log
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// fakeHandler is a minimal Handler used to observe whether LogDepth actually reaches Handle, and at what
+// level, without depending on a real backend.
+type fakeHandler struct {
+	enabledLevel Level
+	handled      []Level
+}
+
+func (h *fakeHandler) Enabled(level Level) bool { return level >= h.enabledLevel }
+
+func (h *fakeHandler) Handle(_ context.Context, _ *Record, level Level, _ string) error {
+	h.handled = append(h.handled, level)
+	return nil
+}
+
+func (h *fakeHandler) WithAttrs(_ ...func(*Record)) Handler { return h }
+func (h *fakeHandler) WithGroup(_ string) Handler           { return h }
+
+// TestLogDepthVModuleOverridesHandlerEnabled guards against a vmodule rule that permits a level more
+// verbose than the bound Handler's own Enabled cutoff being dropped anyway: SetVModule exists so a single
+// package can log more verbosely than the rest of the program, which only works if a matching rule bypasses
+// the Handler's Enabled check rather than merely adding another filter on top of it.
+func TestLogDepthVModuleOverridesHandlerEnabled(t *testing.T) {
+	t.Cleanup(func() { _ = SetVModule("") })
+
+	tests := []struct {
+		name    string
+		vmodule string
+		want    bool
+	}{
+		{name: "no matching rule falls back to Handler.Enabled", vmodule: "", want: false},
+		{name: "rule demotes the call site below its own level", vmodule: "log-go=error", want: false},
+		{name: "rule permits a level the Handler would otherwise reject", vmodule: "log-go=debug", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetVModule(tt.vmodule); err != nil {
+				t.Fatalf("SetVModule(%q): %v", tt.vmodule, err)
+			}
+
+			h := &fakeHandler{enabledLevel: LevelWarn}
+			ctx := NewContext(context.Background(), h)
+
+			Debug("msg", Context(ctx))
+
+			if got := len(h.handled) == 1; got != tt.want {
+				t.Errorf("event handled = %v, want %v (handled: %v)", got, tt.want, h.handled)
+			}
+		})
+	}
+}
+
+// TestResolveVModuleMatchesFilePatternAsSuffix guards against a file-path vmodule pattern never matching in
+// practice: runtime.Caller always returns an absolute build path, so a pattern written relative to the
+// source tree (e.g. "internal/auth/token.go") must match as a path suffix, the same convention
+// backtraceMatch uses, rather than only via a literal path.Match against the whole absolute path.
+func TestResolveVModuleMatchesFilePatternAsSuffix(t *testing.T) {
+	t.Cleanup(func() { _ = SetVModule("") })
+
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("runtime.Caller(0) failed")
+	}
+	base := filepath.Base(file)
+
+	if err := SetVModule(base + "=trace"); err != nil {
+		t.Fatalf("SetVModule(%q): %v", base, err)
+	}
+
+	if !V(LevelTrace) {
+		t.Errorf("V(LevelTrace) = false, want true: pattern %q should match this file via a path suffix", base)
+	}
+}
+
+// TestResolveVModuleMatchesGlobPatternAcrossDirectory covers a multi-segment glob pattern (e.g. "mypkg/*"
+// from SetVModule's own doc example), confirming fileMatch applies path.Match against the trailing segments
+// of the call site's file rather than only the literal whole path.
+func TestResolveVModuleMatchesGlobPatternAcrossDirectory(t *testing.T) {
+	t.Cleanup(func() { _ = SetVModule("") })
+
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("runtime.Caller(0) failed")
+	}
+	pattern := filepath.Join(filepath.Base(filepath.Dir(file)), "*")
+
+	if err := SetVModule(pattern + "=trace"); err != nil {
+		t.Fatalf("SetVModule(%q): %v", pattern, err)
+	}
+
+	if !V(LevelTrace) {
+		t.Errorf("V(LevelTrace) = false, want true: glob pattern %q should match this file via its trailing segments", pattern)
+	}
+}